@@ -14,6 +14,8 @@ import (
 	"ReviewerAssignmentService/internal/config"
 	"ReviewerAssignmentService/internal/database"
 	"ReviewerAssignmentService/internal/handler"
+	"ReviewerAssignmentService/internal/repository"
+	"ReviewerAssignmentService/internal/repository/memory"
 	"ReviewerAssignmentService/internal/repository/postgres"
 	"ReviewerAssignmentService/internal/service"
 )
@@ -25,24 +27,66 @@ func main() {
 	slog.SetDefault(logger)
 
 	cfg := config.New()
-	slog.Info("Starting service", "port", cfg.ServerPort, "env", "dev")
+	slog.Info("Starting service", "port", cfg.ServerPort, "env", "dev", "storage", cfg.Storage)
 
-	dbPool, err := database.NewDBPool(cfg)
+	if err := service.ValidateStrategy(cfg.ReviewerStrategy); err != nil {
+		slog.Error("Invalid reviewer strategy", "strategy", cfg.ReviewerStrategy, "error", err)
+		os.Exit(1)
+	}
+
+	location, err := time.LoadLocation(cfg.WorkTimezone)
+	if err != nil {
+		slog.Error("Invalid work timezone", "timezone", cfg.WorkTimezone, "error", err)
+		os.Exit(1)
+	}
+	workingHours := service.WorkingHours{
+		StartHour: cfg.WorkdayStartHour,
+		EndHour:   cfg.WorkdayEndHour,
+		Location:  location,
+	}
+	if err := workingHours.Validate(); err != nil {
+		slog.Error("Invalid working hours", "start", cfg.WorkdayStartHour, "end", cfg.WorkdayEndHour, "error", err)
+		os.Exit(1)
+	}
+
+	store, err := openStorage(cfg)
 	if err != nil {
-		slog.Error("Failed to init DB", "error", err)
+		slog.Error("Failed to init storage", "error", err)
 		os.Exit(1)
 	}
-	defer dbPool.Close()
+	defer store.close()
+
+	teamService := service.NewTeamService(store.team, store.user, store.ownership)
+	userService := service.NewUserService(store.user, store.pr)
+	prService := service.NewPRService(store.pr, store.user, store.team, store.ownership, store.unitOfWork,
+		service.AssignmentConfig{
+			Strategy:       cfg.ReviewerStrategy,
+			MaxOpenReviews: cfg.MaxOpenReviews,
+			MergeAdmins:    cfg.MergeAdmins,
+			WorkingHours:   workingHours,
+		})
+
+	webhookService := service.NewWebhookService(store.webhook, service.WebhookConfig{
+		MaxAttempts: cfg.WebhookMaxAttempts,
+		BaseBackoff: cfg.WebhookBackoff,
+		Timeout:     cfg.WebhookTimeout,
+	})
 
-	teamRepo := postgres.NewTeamRepository(dbPool)
-	userRepo := postgres.NewUserRepository(dbPool)
-	prRepo := postgres.NewPrRepository(dbPool)
+	integrationService := service.NewIntegrationService(prService, store.pr, store.user, store.identity,
+		service.IntegrationConfig{
+			GitHubSecret: cfg.GitHubWebhookSecret,
+			GitLabToken:  cfg.GitLabWebhookToken,
+		})
 
-	teamService := service.NewTeamService(teamRepo)
-	userService := service.NewUserService(userRepo, prRepo)
-	prService := service.NewPRService(prRepo, userRepo)
+	idempotencyService := service.NewIdempotencyService(store.idempotency, cfg.IdempotencyWindow)
 
-	httpHandler := handler.New(teamService, userService, prService)
+	httpHandler := handler.New(teamService, userService, prService,
+		webhookService, integrationService, idempotencyService)
+
+	workerCtx, stopWorker := context.WithCancel(context.Background())
+	defer stopWorker()
+	go runSLAWorker(workerCtx, prService, store.locker, cfg.SLACheckInterval)
+	go runWebhookWorker(workerCtx, webhookService, store.locker, cfg.WebhookInterval)
 
 	srv := &http.Server{
 		Addr:    fmt.Sprintf(":%d", cfg.ServerPort),
@@ -62,6 +106,7 @@ func main() {
 	<-quit
 
 	slog.Info("Shutting down server...")
+	stopWorker()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -72,3 +117,135 @@ func main() {
 
 	slog.Info("Server exited properly")
 }
+
+type storage struct {
+	team        repository.TeamRepository
+	user        repository.UserRepository
+	pr          repository.PRRepository
+	ownership   repository.OwnershipRepository
+	webhook     repository.WebhookRepository
+	identity    repository.IdentityRepository
+	idempotency repository.IdempotencyRepository
+	unitOfWork  repository.UnitOfWork
+	locker      repository.Locker
+	close       func()
+}
+
+// openStorage builds the repositories of the backend selected by cfg.Storage.
+// The in-memory backend keeps no data across restarts and is meant for local
+// development and tests.
+func openStorage(cfg *config.Config) (*storage, error) {
+	switch cfg.Storage {
+	case config.StoragePostgres:
+		dbPool, err := database.NewDBPool(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return &storage{
+			team:        postgres.NewTeamRepository(dbPool),
+			user:        postgres.NewUserRepository(dbPool),
+			pr:          postgres.NewPrRepository(dbPool),
+			ownership:   postgres.NewOwnershipRepository(dbPool),
+			webhook:     postgres.NewWebhookRepository(dbPool),
+			identity:    postgres.NewIdentityRepository(dbPool),
+			idempotency: postgres.NewIdempotencyRepository(dbPool),
+			unitOfWork:  postgres.NewUnitOfWork(dbPool),
+			locker:      postgres.NewAdvisoryLocker(dbPool),
+			close:       dbPool.Close,
+		}, nil
+	case config.StorageMemory:
+		store := memory.NewStore()
+		return &storage{
+			team:        memory.NewTeamRepository(store),
+			user:        memory.NewUserRepository(store),
+			pr:          memory.NewPrRepository(store),
+			ownership:   memory.NewOwnershipRepository(store),
+			webhook:     memory.NewWebhookRepository(store),
+			identity:    memory.NewIdentityRepository(store),
+			idempotency: memory.NewIdempotencyRepository(store),
+			unitOfWork:  memory.NewUnitOfWork(store),
+			locker:      memory.NewLocker(),
+			close:       func() {},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown storage %q, expected %q or %q",
+			cfg.Storage, config.StoragePostgres, config.StorageMemory)
+	}
+}
+
+// Advisory lock keys that let only one replica run each background job at a time.
+const (
+	slaWorkerLockKey     int64 = 0x534c41
+	webhookWorkerLockKey int64 = 0x574842
+)
+
+// runSLAWorker periodically flags reviews that missed their team's SLA and
+// escalates the ones past the team's escalation threshold.
+func runSLAWorker(ctx context.Context, prService service.PRService, locker repository.Locker, interval time.Duration) {
+	if interval <= 0 {
+		slog.Info("SLA worker disabled")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			ran, err := locker.TryWithLock(ctx, slaWorkerLockKey, func(ctx context.Context) error {
+				marked, err := prService.MarkOverdueReviews(ctx, now)
+				if err != nil {
+					return err
+				}
+				escalated, err := prService.EscalateStaleReviews(ctx, now)
+				if err != nil {
+					return err
+				}
+				if marked > 0 || escalated > 0 {
+					slog.Info("SLA check finished", "overdue", marked, "escalated", escalated)
+				}
+				return nil
+			})
+			if err != nil {
+				slog.Error("SLA check failed", "error", err)
+				continue
+			}
+			if !ran {
+				slog.Debug("SLA check skipped: running on another replica")
+			}
+		}
+	}
+}
+
+// runWebhookWorker periodically delivers new PR events to webhook subscribers.
+func runWebhookWorker(ctx context.Context, webhookService service.WebhookService,
+	locker repository.Locker, interval time.Duration) {
+	if interval <= 0 {
+		slog.Info("Webhook worker disabled")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, err := locker.TryWithLock(ctx, webhookWorkerLockKey, func(ctx context.Context) error {
+				delivered, err := webhookService.DispatchPending(ctx, time.Now())
+				if delivered > 0 {
+					slog.Info("Webhooks delivered", "count", delivered)
+				}
+				return err
+			})
+			if err != nil && !errors.Is(err, context.Canceled) {
+				slog.Error("Webhook dispatch failed", "error", err)
+			}
+		}
+	}
+}
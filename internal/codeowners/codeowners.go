@@ -0,0 +1,144 @@
+package codeowners
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"ReviewerAssignmentService/internal/domains"
+)
+
+const TeamOwnerPrefix = "@"
+
+var ErrInvalidRule = errors.New("invalid ownership rule")
+
+// Parse reads a CODEOWNERS-like document: one "pattern owner..." rule per line,
+// where an owner is a user_id or "@team_name". Blank lines and # comments are skipped.
+func Parse(content string) ([]domains.OwnershipRule, error) {
+	rules := make([]domains.OwnershipRule, 0)
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+
+		line := scanner.Text()
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		pattern := fields[0]
+		if strings.HasPrefix(pattern, "!") {
+			return nil, fmt.Errorf("%w: line %d: negated patterns are not supported", ErrInvalidRule, lineNumber)
+		}
+		if _, err := compile(pattern); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidRule, lineNumber, err)
+		}
+
+		owners := fields[1:]
+		for _, owner := range owners {
+			if owner == TeamOwnerPrefix {
+				return nil, fmt.Errorf("%w: line %d: empty team owner", ErrInvalidRule, lineNumber)
+			}
+		}
+
+		rules = append(rules, domains.OwnershipRule{Pattern: pattern, Owners: owners})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// Ruleset holds ownership rules with their patterns compiled once, so that
+// matching many files against it does not rebuild any regexp.
+type Ruleset struct {
+	rules    []domains.OwnershipRule
+	patterns []*regexp.Regexp
+}
+
+// NewRuleset compiles the patterns of rules. Rules with a pattern that does not
+// compile never match; Parse rejects such patterns before they are stored.
+func NewRuleset(rules []domains.OwnershipRule) *Ruleset {
+	patterns := make([]*regexp.Regexp, len(rules))
+	for i, rule := range rules {
+		patterns[i], _ = compile(rule.Pattern)
+	}
+	return &Ruleset{rules: rules, patterns: patterns}
+}
+
+// Owners returns the owners of path. As in CODEOWNERS, the last matching rule wins.
+func (r *Ruleset) Owners(path string) []string {
+	path = strings.TrimPrefix(path, "/")
+	for i := len(r.rules) - 1; i >= 0; i-- {
+		if r.patterns[i] != nil && r.patterns[i].MatchString(path) {
+			return r.rules[i].Owners
+		}
+	}
+	return nil
+}
+
+func Match(pattern, path string) bool {
+	re, err := compile(pattern)
+	if err != nil {
+		return false
+	}
+	return re.MatchString(strings.TrimPrefix(path, "/"))
+}
+
+func SplitOwners(owners []string) (userIDs []string, teamNames []string) {
+	for _, owner := range owners {
+		if strings.HasPrefix(owner, TeamOwnerPrefix) {
+			teamNames = append(teamNames, strings.TrimPrefix(owner, TeamOwnerPrefix))
+		} else {
+			userIDs = append(userIDs, owner)
+		}
+	}
+	return userIDs, teamNames
+}
+
+func compile(pattern string) (*regexp.Regexp, error) {
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	pattern = strings.Trim(pattern, "/")
+	if pattern == "" {
+		return nil, errors.New("empty pattern")
+	}
+
+	var expr strings.Builder
+	if anchored {
+		expr.WriteString("^")
+	} else {
+		expr.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case c == '*':
+			expr.WriteString("[^/]*")
+		case c == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	if strings.HasSuffix(pattern, "/*") && !strings.HasSuffix(pattern, "**") {
+		expr.WriteString("$")
+	} else {
+		expr.WriteString("(?:/.*)?$")
+	}
+
+	return regexp.Compile(expr.String())
+}
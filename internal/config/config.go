@@ -3,6 +3,13 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	StoragePostgres = "postgres"
+	StorageMemory   = "memory"
 )
 
 const (
@@ -12,6 +19,23 @@ const (
 	DefaultDBUser     = "postgres"
 	DefaultDBPassword = "password"
 	DefaultDBName     = "pr_service"
+	DefaultStorage    = StoragePostgres
+
+	DefaultReviewerStrategy = "least_loaded"
+	DefaultMaxOpenReviews   = 0
+
+	DefaultSLACheckIntervalSeconds = 300
+
+	DefaultWebhookIntervalSeconds = 5
+	DefaultWebhookMaxAttempts     = 5
+	DefaultWebhookBackoffMillis   = 1000
+	DefaultWebhookTimeoutSeconds  = 10
+
+	DefaultIdempotencyWindowSeconds = 24 * 60 * 60
+
+	DefaultWorkdayStartHour = 9
+	DefaultWorkdayEndHour   = 18
+	DefaultWorkTimezone     = "UTC"
 )
 
 type Config struct {
@@ -21,6 +45,28 @@ type Config struct {
 	DBName     string
 	DBPort     int
 	ServerPort int
+
+	Storage string
+
+	ReviewerStrategy string
+	MaxOpenReviews   int
+
+	SLACheckInterval time.Duration
+	WorkdayStartHour int
+	WorkdayEndHour   int
+	WorkTimezone     string
+
+	WebhookInterval    time.Duration
+	WebhookMaxAttempts int
+	WebhookBackoff     time.Duration
+	WebhookTimeout     time.Duration
+
+	GitHubWebhookSecret string
+	GitLabWebhookToken  string
+
+	IdempotencyWindow time.Duration
+
+	MergeAdmins []string
 }
 
 func New() *Config {
@@ -31,6 +77,28 @@ func New() *Config {
 		DBName:     getEnvString("DB_NAME", DefaultDBName),
 		DBPort:     getEnvInt("DB_PORT", DefaultDBPort),
 		ServerPort: getEnvInt("SERVER_PORT", DefaultServerPort),
+
+		Storage: getEnvString("STORAGE", DefaultStorage),
+
+		ReviewerStrategy: getEnvString("REVIEWER_STRATEGY", DefaultReviewerStrategy),
+		MaxOpenReviews:   getEnvInt("MAX_OPEN_REVIEWS", DefaultMaxOpenReviews),
+
+		SLACheckInterval: time.Duration(getEnvInt("SLA_CHECK_INTERVAL_SECONDS", DefaultSLACheckIntervalSeconds)) * time.Second,
+		WorkdayStartHour: getEnvInt("WORKDAY_START_HOUR", DefaultWorkdayStartHour),
+		WorkdayEndHour:   getEnvInt("WORKDAY_END_HOUR", DefaultWorkdayEndHour),
+		WorkTimezone:     getEnvString("WORK_TIMEZONE", DefaultWorkTimezone),
+
+		WebhookInterval:    time.Duration(getEnvInt("WEBHOOK_INTERVAL_SECONDS", DefaultWebhookIntervalSeconds)) * time.Second,
+		WebhookMaxAttempts: getEnvInt("WEBHOOK_MAX_ATTEMPTS", DefaultWebhookMaxAttempts),
+		WebhookBackoff:     time.Duration(getEnvInt("WEBHOOK_BACKOFF_MS", DefaultWebhookBackoffMillis)) * time.Millisecond,
+		WebhookTimeout:     time.Duration(getEnvInt("WEBHOOK_TIMEOUT_SECONDS", DefaultWebhookTimeoutSeconds)) * time.Second,
+
+		GitHubWebhookSecret: getEnvString("GITHUB_WEBHOOK_SECRET", ""),
+		GitLabWebhookToken:  getEnvString("GITLAB_WEBHOOK_TOKEN", ""),
+
+		IdempotencyWindow: time.Duration(getEnvInt("IDEMPOTENCY_WINDOW_SECONDS", DefaultIdempotencyWindowSeconds)) * time.Second,
+
+		MergeAdmins: getEnvList("MERGE_ADMINS"),
 	}
 }
 
@@ -49,3 +117,14 @@ func getEnvInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+// getEnvList splits a comma-separated variable, dropping blank entries.
+func getEnvList(key string) []string {
+	values := make([]string, 0)
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1 @@
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS max_open_reviews INTEGER NULL CHECK (max_open_reviews >= 0);
@@ -0,0 +1 @@
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS required_reviewers INTEGER NOT NULL DEFAULT 2 CHECK (required_reviewers >= 0);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS ownership_rules (
+    id SERIAL PRIMARY KEY,
+    team_id INTEGER NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    pattern VARCHAR(1024) NOT NULL,
+    owners JSONB NOT NULL DEFAULT '[]',
+    UNIQUE (team_id, position)
+);
+
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS owner_reviewers JSONB NOT NULL DEFAULT '[]';
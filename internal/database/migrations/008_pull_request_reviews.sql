@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS pull_request_reviews (
+    pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    reviewer_id VARCHAR(255) NOT NULL,
+    state VARCHAR(32) NOT NULL DEFAULT 'PENDING',
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (pull_request_id, reviewer_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pr_reviews_reviewer ON pull_request_reviews(reviewer_id);
+
+INSERT INTO pull_request_reviews (pull_request_id, reviewer_id, state, updated_at)
+SELECT pr.pull_request_id, reviewer.id, 'PENDING', COALESCE(pr.created_at, CURRENT_TIMESTAMP)
+FROM pull_requests pr
+CROSS JOIN LATERAL jsonb_array_elements_text(pr.assigned_reviewers) AS reviewer(id)
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,13 @@
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS min_approvals INTEGER NOT NULL DEFAULT 0 CHECK (min_approvals >= 0);
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS require_owner_approval BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS merge_overrides (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    forced_by VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    unmet_conditions JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_merge_overrides_pr ON merge_overrides(pull_request_id);
@@ -0,0 +1,53 @@
+CREATE TABLE IF NOT EXISTS pull_request_reviewers (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    reviewer_id VARCHAR(255) NOT NULL REFERENCES users(user_id),
+    position INTEGER NOT NULL DEFAULT 0,
+    is_owner BOOLEAN NOT NULL DEFAULT FALSE,
+    state VARCHAR(32) NOT NULL DEFAULT 'PENDING',
+    state_updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    assigned_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    replaced_at TIMESTAMP WITH TIME ZONE NULL,
+    replaced_by VARCHAR(255) NULL REFERENCES users(user_id),
+    reason VARCHAR(64) NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_pr_reviewers_active
+    ON pull_request_reviewers(pull_request_id, reviewer_id) WHERE replaced_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_pr_reviewers_reviewer_active
+    ON pull_request_reviewers(reviewer_id) WHERE replaced_at IS NULL;
+
+DO $$
+DECLARE
+    orphaned INTEGER;
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_name = 'pull_requests' AND column_name = 'assigned_reviewers'
+    ) THEN
+        SELECT COUNT(*) INTO orphaned
+        FROM pull_requests pr
+        CROSS JOIN LATERAL jsonb_array_elements_text(pr.assigned_reviewers) AS reviewer(id)
+        WHERE NOT EXISTS (SELECT 1 FROM users u WHERE u.user_id = reviewer.id);
+
+        IF orphaned > 0 THEN
+            RAISE EXCEPTION '% assigned reviewers do not exist in users, fix them before migrating', orphaned;
+        END IF;
+
+        INSERT INTO pull_request_reviewers (
+            pull_request_id, reviewer_id, position, is_owner, state, state_updated_at, assigned_at
+        )
+        SELECT pr.pull_request_id,
+               reviewer.id,
+               reviewer.ord - 1,
+               pr.owner_reviewers @> to_jsonb(reviewer.id),
+               'PENDING',
+               COALESCE(pr.created_at, CURRENT_TIMESTAMP),
+               COALESCE(pr.created_at, CURRENT_TIMESTAMP)
+        FROM pull_requests pr
+        CROSS JOIN LATERAL jsonb_array_elements_text(pr.assigned_reviewers) WITH ORDINALITY AS reviewer(id, ord);
+
+        ALTER TABLE pull_requests DROP COLUMN assigned_reviewers;
+        ALTER TABLE pull_requests DROP COLUMN owner_reviewers;
+    END IF;
+END $$;
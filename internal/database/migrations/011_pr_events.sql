@@ -0,0 +1,49 @@
+CREATE TABLE IF NOT EXISTS pr_events (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    event_type VARCHAR(32) NOT NULL,
+    actor VARCHAR(255) NULL,
+    reviewer_id VARCHAR(255) NULL,
+    replaced_by VARCHAR(255) NULL,
+    state VARCHAR(32) NULL,
+    reason TEXT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_pr_events_pr ON pr_events(pull_request_id, id);
+
+CREATE OR REPLACE FUNCTION pr_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'pr_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS pr_events_no_update ON pr_events;
+CREATE TRIGGER pr_events_no_update
+    BEFORE UPDATE OR DELETE ON pr_events
+    FOR EACH ROW EXECUTE FUNCTION pr_events_append_only();
+
+INSERT INTO pr_events (pull_request_id, event_type, created_at)
+SELECT pull_request_id, 'created', COALESCE(created_at, CURRENT_TIMESTAMP)
+FROM pull_requests;
+
+INSERT INTO pr_events (pull_request_id, event_type, reviewer_id, created_at)
+SELECT pull_request_id, 'reviewer_assigned', reviewer_id, assigned_at
+FROM pull_request_reviewers;
+
+INSERT INTO pr_events (pull_request_id, event_type, reviewer_id, replaced_by, reason, created_at)
+SELECT pull_request_id,
+       CASE WHEN replaced_by IS NULL THEN 'reviewer_removed' ELSE 'reviewer_replaced' END,
+       reviewer_id, replaced_by, reason, replaced_at
+FROM pull_request_reviewers
+WHERE replaced_at IS NOT NULL;
+
+INSERT INTO pr_events (pull_request_id, event_type, created_at)
+SELECT pull_request_id, 'merged', merged_at
+FROM pull_requests pr
+WHERE merged_at IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM merge_overrides mo WHERE mo.pull_request_id = pr.pull_request_id);
+
+INSERT INTO pr_events (pull_request_id, event_type, actor, reason, created_at)
+SELECT pull_request_id, 'merge_forced', forced_by, NULLIF(reason, ''), COALESCE(created_at, CURRENT_TIMESTAMP)
+FROM merge_overrides;
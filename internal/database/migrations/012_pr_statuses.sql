@@ -0,0 +1,5 @@
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_status_check;
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_status_check
+    CHECK (status IN ('DRAFT', 'OPEN', 'MERGED', 'CLOSED', 'REOPENED'));
+
+CREATE INDEX IF NOT EXISTS idx_pr_status ON pull_requests(status);
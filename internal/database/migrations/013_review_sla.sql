@@ -0,0 +1,6 @@
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS review_sla_hours INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE pull_request_reviewers ADD COLUMN IF NOT EXISTS overdue_at TIMESTAMP WITH TIME ZONE NULL;
+
+CREATE INDEX IF NOT EXISTS idx_pr_reviewers_pending
+    ON pull_request_reviewers(assigned_at) WHERE replaced_at IS NULL AND state = 'PENDING';
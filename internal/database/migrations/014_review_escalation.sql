@@ -0,0 +1,4 @@
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS escalation_hours INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_pr_reviewers_timed_out
+    ON pull_request_reviewers(pull_request_id) WHERE reason = 'sla_timeout';
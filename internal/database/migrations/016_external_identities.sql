@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS external_identities (
+    provider VARCHAR(32) NOT NULL,
+    login VARCHAR(255) NOT NULL,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (provider, login),
+    CHECK (provider IN ('github', 'gitlab'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_external_identities_user ON external_identities(user_id);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS webhook_pending_deliveries (
+    subscription_id BIGINT NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    event_id BIGINT NOT NULL REFERENCES pr_events(id),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (subscription_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_pending_due ON webhook_pending_deliveries(subscription_id, next_attempt_at);
+
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMP WITH TIME ZONE NULL;
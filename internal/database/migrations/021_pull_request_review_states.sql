@@ -0,0 +1,17 @@
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.tables
+        WHERE table_name = 'pull_request_reviews'
+    ) THEN
+        UPDATE pull_request_reviewers prr
+        SET state = rv.state,
+            state_updated_at = rv.updated_at
+        FROM pull_request_reviews rv
+        WHERE rv.pull_request_id = prr.pull_request_id
+          AND rv.reviewer_id = prr.reviewer_id
+          AND prr.replaced_at IS NULL;
+    END IF;
+END $$;
+
+DROP TABLE IF EXISTS pull_request_reviews;
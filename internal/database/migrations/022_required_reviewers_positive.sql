@@ -0,0 +1,4 @@
+UPDATE teams SET required_reviewers = 2 WHERE required_reviewers <= 0;
+
+ALTER TABLE teams DROP CONSTRAINT IF EXISTS teams_required_reviewers_check;
+ALTER TABLE teams ADD CONSTRAINT teams_required_reviewers_check CHECK (required_reviewers > 0);
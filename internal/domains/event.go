@@ -0,0 +1,47 @@
+package domains
+
+import (
+	"context"
+	"time"
+)
+
+type PREventType string
+
+const (
+	PREventCreated          PREventType = "created"
+	PREventReviewerAssigned PREventType = "reviewer_assigned"
+	PREventReviewerReplaced PREventType = "reviewer_replaced"
+	PREventReviewerRemoved  PREventType = "reviewer_removed"
+	PREventReviewSubmitted  PREventType = "review_submitted"
+	PREventMerged           PREventType = "merged"
+	PREventMergeForced      PREventType = "merge_forced"
+	PREventClosed           PREventType = "closed"
+	PREventReopened         PREventType = "reopened"
+	PREventReady            PREventType = "ready_for_review"
+	PREventReviewOverdue    PREventType = "review_overdue"
+)
+
+type PREvent struct {
+	ID            int64       `json:"id" db:"id"`
+	PullRequestID string      `json:"pull_request_id" db:"pull_request_id"`
+	Type          PREventType `json:"type" db:"event_type"`
+	Actor         string      `json:"actor,omitempty" db:"actor"`
+	ReviewerID    string      `json:"reviewer_id,omitempty" db:"reviewer_id"`
+	ReplacedBy    string      `json:"replaced_by,omitempty" db:"replaced_by"`
+	State         ReviewState `json:"state,omitempty" db:"state"`
+	Reason        string      `json:"reason,omitempty" db:"reason"`
+	CreatedAt     time.Time   `json:"created_at" db:"created_at"`
+}
+
+type actorKey struct{}
+
+// WithActor records who initiated the request, so that events written further
+// down the call chain can be attributed to them.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
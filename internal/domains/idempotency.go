@@ -0,0 +1,15 @@
+package domains
+
+import "time"
+
+// IdempotentResponse is the first response given to a request carrying an
+// Idempotency-Key, kept so that retries get exactly the same answer.
+type IdempotentResponse struct {
+	Key         string    `db:"idempotency_key"`
+	Endpoint    string    `db:"endpoint"`
+	RequestHash string    `db:"request_hash"`
+	StatusCode  int       `db:"status_code"`
+	Body        []byte    `db:"body"`
+	Completed   bool      `db:"completed"`
+	ExpiresAt   time.Time `db:"expires_at"`
+}
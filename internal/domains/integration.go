@@ -0,0 +1,45 @@
+package domains
+
+type Provider string
+
+const (
+	ProviderGitHub Provider = "github"
+	ProviderGitLab Provider = "gitlab"
+)
+
+// ExternalIdentity maps a login on a code hosting provider to a service user.
+type ExternalIdentity struct {
+	Provider Provider `json:"provider" db:"provider"`
+	Login    string   `json:"login" db:"login"`
+	UserID   string   `json:"user_id" db:"user_id"`
+}
+
+type ExternalAction string
+
+const (
+	ExternalOpened   ExternalAction = "opened"
+	ExternalReady    ExternalAction = "ready_for_review"
+	ExternalMerged   ExternalAction = "merged"
+	ExternalClosed   ExternalAction = "closed"
+	ExternalReopened ExternalAction = "reopened"
+)
+
+// ExternalPREvent is a provider pull/merge request event reduced to what the
+// service acts on. An empty Action means the event is not relevant.
+type ExternalPREvent struct {
+	Provider      Provider
+	Action        ExternalAction
+	PullRequestID string
+	Title         string
+	AuthorLogin   string
+	SenderLogin   string
+	Draft         bool
+}
+
+type IntegrationResult struct {
+	Provider      Provider       `json:"provider"`
+	Action        ExternalAction `json:"action,omitempty"`
+	PullRequestID string         `json:"pull_request_id,omitempty"`
+	Ignored       bool           `json:"ignored"`
+	PullRequest   *PullRequest   `json:"pr,omitempty"`
+}
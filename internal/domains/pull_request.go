@@ -5,29 +5,105 @@ import "time"
 type PRStatus string
 
 const (
-	PRStatusOpen   PRStatus = "OPEN"
-	PRStatusMerged PRStatus = "MERGED"
+	PRStatusDraft    PRStatus = "DRAFT"
+	PRStatusOpen     PRStatus = "OPEN"
+	PRStatusMerged   PRStatus = "MERGED"
+	PRStatusClosed   PRStatus = "CLOSED"
+	PRStatusReopened PRStatus = "REOPENED"
 )
 
+// InReview reports whether the PR is waiting on its reviewers.
+func (s PRStatus) InReview() bool {
+	return s == PRStatusOpen || s == PRStatusReopened
+}
+
+type ReviewState string
+
+const (
+	ReviewStatePending          ReviewState = "PENDING"
+	ReviewStateApproved         ReviewState = "APPROVED"
+	ReviewStateChangesRequested ReviewState = "CHANGES_REQUESTED"
+)
+
+type Review struct {
+	ReviewerID string      `json:"reviewer_id" db:"reviewer_id"`
+	State      ReviewState `json:"state" db:"state"`
+	UpdatedAt  *time.Time  `json:"updated_at,omitempty" db:"updated_at"`
+}
+
 type PullRequest struct {
 	ID                string     `json:"pull_request_id" db:"pull_request_id"`
 	Name              string     `json:"pull_request_name" db:"pull_request_name"`
 	AuthorID          string     `json:"author_id" db:"author_id"`
 	Status            PRStatus   `json:"status" db:"status"`
 	AssignedReviewers []string   `json:"assigned_reviewers" db:"assigned_reviewers"`
+	OwnerReviewers    []string   `json:"owner_reviewers,omitempty" db:"owner_reviewers"`
+	ChangedFiles      []string   `json:"changed_files,omitempty" db:"changed_files"`
+	Reviews           []Review   `json:"reviews" db:"-"`
+	FallbackReviewers []string   `json:"fallback_reviewers,omitempty" db:"-"`
+	AssignmentPolicy  string     `json:"assignment_policy,omitempty" db:"-"`
+	AssignmentNotes   []string   `json:"assignment_notes,omitempty" db:"-"`
+	ChangeReason      string     `json:"-" db:"-"`
+	Version           int        `json:"-" db:"version"`
 	CreatedAt         *time.Time `json:"createdAt,omitempty" db:"created_at"`
 	MergedAt          *time.Time `json:"mergedAt,omitempty" db:"merged_at"`
 }
 
 type PullRequestShort struct {
-	ID       string   `json:"pull_request_id" db:"pull_request_id"`
-	Name     string   `json:"pull_request_name" db:"pull_request_name"`
-	AuthorID string   `json:"author_id" db:"author_id"`
-	Status   PRStatus `json:"status" db:"status"`
+	ID              string      `json:"pull_request_id" db:"pull_request_id"`
+	Name            string      `json:"pull_request_name" db:"pull_request_name"`
+	AuthorID        string      `json:"author_id" db:"author_id"`
+	Status          PRStatus    `json:"status" db:"status"`
+	ReviewState     ReviewState `json:"review_state" db:"review_state"`
+	ReviewUpdatedAt *time.Time  `json:"review_updated_at,omitempty" db:"review_updated_at"`
 }
 
 type PullRequestInput struct {
-	ID       string
-	Name     string
-	AuthorID string
+	ID           string
+	Name         string
+	AuthorID     string
+	ChangedFiles []string
+	Draft        bool
+}
+
+const (
+	ReplacementReassigned  = "reassigned"
+	ReplacementDeactivated = "deactivated"
+	ReplacementClosed      = "pr_closed"
+	ReplacementRemoved     = "removed"
+	ReplacementSLATimeout  = "sla_timeout"
+)
+
+type MergeOverride struct {
+	PullRequestID   string   `json:"pull_request_id" db:"pull_request_id"`
+	ForcedBy        string   `json:"forced_by" db:"forced_by"`
+	Reason          string   `json:"reason,omitempty" db:"reason"`
+	UnmetConditions []string `json:"unmet_conditions" db:"unmet_conditions"`
+}
+
+type Reassignment struct {
+	PullRequestID string `json:"pull_request_id"`
+	OldUserID     string `json:"old_user_id"`
+	NewUserID     string `json:"replaced_by,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+}
+
+type ReassignmentReport struct {
+	Reassigned []Reassignment `json:"reassigned"`
+	Unassigned []Reassignment `json:"unassigned"`
+}
+
+// PendingReview is an assignment still waiting for the reviewer's first verdict,
+// measured against the SLA of the author's team.
+type PendingReview struct {
+	PullRequestID   string     `json:"pull_request_id" db:"pull_request_id"`
+	PullRequestName string     `json:"pull_request_name" db:"pull_request_name"`
+	AuthorID        string     `json:"author_id" db:"author_id"`
+	TeamName        string     `json:"team_name" db:"team_name"`
+	ReviewerID      string     `json:"reviewer_id" db:"reviewer_id"`
+	AssignedAt      time.Time  `json:"assigned_at" db:"assigned_at"`
+	OverdueAt       *time.Time `json:"overdue_at,omitempty" db:"overdue_at"`
+	SLAHours        int        `json:"sla_hours" db:"review_sla_hours"`
+	EscalationHours int        `json:"escalation_hours,omitempty" db:"escalation_hours"`
+	AgeHours        float64    `json:"age_hours" db:"-"`
 }
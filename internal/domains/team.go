@@ -1,12 +1,41 @@
 package domains
 
+const DefaultRequiredReviewers = 2
+
 type TeamMember struct {
 	UserID   string `json:"user_id" db:"user_id"`
 	UserName string `json:"username" db:"username"`
 	IsActive bool   `json:"is_active" db:"is_active"`
 }
 
+type TeamSettings struct {
+	ReviewerStrategy  string   `json:"reviewer_strategy,omitempty" db:"reviewer_strategy"`
+	MaxOpenReviews    *int     `json:"max_open_reviews,omitempty" db:"max_open_reviews"`
+	RequiredReviewers int      `json:"required_reviewers" db:"required_reviewers"`
+	FallbackTeams     []string `json:"fallback_teams,omitempty" db:"fallback_teams"`
+	ReviewSLAHours    int      `json:"review_sla_hours,omitempty" db:"review_sla_hours"`
+	EscalationHours   int      `json:"escalation_hours,omitempty" db:"escalation_hours"`
+	MergePolicy
+}
+
+type MergePolicy struct {
+	MinApprovals         int  `json:"min_approvals" db:"min_approvals"`
+	RequireOwnerApproval bool `json:"require_owner_approval" db:"require_owner_approval"`
+}
+
 type Team struct {
 	Name    string       `json:"team_name" db:"team_name"`
 	Members []TeamMember `json:"members" db:"members"`
+	TeamSettings
+}
+
+type OwnershipRule struct {
+	Pattern string   `json:"pattern" db:"pattern"`
+	Owners  []string `json:"owners" db:"owners"`
+}
+
+type TeamDeactivation struct {
+	TeamName         string              `json:"team_name"`
+	DeactivatedUsers []string            `json:"deactivated_users"`
+	Reassignments    *ReassignmentReport `json:"reassignments"`
 }
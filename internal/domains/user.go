@@ -1,8 +1,31 @@
 package domains
 
+import "time"
+
 type User struct {
 	ID       string `json:"user_id" db:"user_id"`
 	Name     string `json:"username" db:"username"`
 	TeamName string `json:"team_name" db:"team_name"`
 	IsActive bool   `json:"is_active" db:"is_active"`
 }
+
+type ReviewCandidate struct {
+	UserID      string `json:"user_id" db:"user_id"`
+	OpenReviews int    `json:"open_reviews" db:"open_reviews"`
+}
+
+type UnavailabilityReason string
+
+const (
+	UnavailabilityVacation  UnavailabilityReason = "VACATION"
+	UnavailabilitySickLeave UnavailabilityReason = "SICK_LEAVE"
+	UnavailabilityOther     UnavailabilityReason = "OTHER"
+)
+
+type Unavailability struct {
+	ID       int64                `json:"id" db:"id"`
+	UserID   string               `json:"user_id" db:"user_id"`
+	StartsAt time.Time            `json:"starts_at" db:"starts_at"`
+	EndsAt   time.Time            `json:"ends_at" db:"ends_at"`
+	Reason   UnavailabilityReason `json:"reason" db:"reason"`
+}
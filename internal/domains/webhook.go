@@ -0,0 +1,44 @@
+package domains
+
+import "time"
+
+// WebhookEvents are the PR events that can be delivered to subscribers.
+var WebhookEvents = []PREventType{
+	PREventCreated,
+	PREventReviewerAssigned,
+	PREventReviewerReplaced,
+	PREventMerged,
+	PREventMergeForced,
+}
+
+type WebhookSubscription struct {
+	ID          int64         `json:"id" db:"id"`
+	URL         string        `json:"url" db:"url"`
+	Secret      string        `json:"secret,omitempty" db:"secret"`
+	Events      []PREventType `json:"events" db:"events"`
+	LastEventID int64         `json:"-" db:"last_event_id"`
+	CreatedAt   time.Time     `json:"created_at" db:"created_at"`
+}
+
+type WebhookDelivery struct {
+	ID             int64       `json:"id" db:"id"`
+	SubscriptionID int64       `json:"subscription_id" db:"subscription_id"`
+	EventID        int64       `json:"event_id" db:"event_id"`
+	EventType      PREventType `json:"event_type" db:"event_type"`
+	Attempt        int         `json:"attempt" db:"attempt"`
+	StatusCode     int         `json:"status_code,omitempty" db:"status_code"`
+	Error          string      `json:"error,omitempty" db:"error"`
+	Success        bool        `json:"success" db:"success"`
+	// NextAttemptAt is when the event is retried after this failed attempt; nil once it is no longer retried.
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty" db:"next_attempt_at"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+}
+
+// PendingDelivery is an event queued for a subscription. It stays queued until it
+// is delivered or runs out of attempts, independently of the events queued after it.
+type PendingDelivery struct {
+	SubscriptionID int64     `db:"subscription_id"`
+	Event          PREvent   `db:"-"`
+	Attempts       int       `db:"attempts"`
+	NextAttemptAt  time.Time `db:"next_attempt_at"`
+}
@@ -1,27 +1,80 @@
 package handler
 
 const (
-	ErrCodeBadRequest    = "BAD_REQUEST"
-	ErrCodeInternalError = "INTERNAL_ERROR"
-	ErrCodeNotFound      = "NOT_FOUND"
-	ErrCodeTeamExists    = "TEAM_EXISTS"
-	ErrCodePRExists      = "PR_EXISTS"
-	ErrCodePRMerged      = "PR_MERGED"
-	ErrCodeNotAssigned   = "NOT_ASSIGNED"
-	ErrCodeNoCandidate   = "NO_CANDIDATE"
+	ErrCodeBadRequest            = "BAD_REQUEST"
+	ErrCodeInternalError         = "INTERNAL_ERROR"
+	ErrCodeNotFound              = "NOT_FOUND"
+	ErrCodeTeamExists            = "TEAM_EXISTS"
+	ErrCodePRExists              = "PR_EXISTS"
+	ErrCodePRMerged              = "PR_MERGED"
+	ErrCodeNotAssigned           = "NOT_ASSIGNED"
+	ErrCodeNoCandidate           = "NO_CANDIDATE"
+	ErrCodeMergeBlocked          = "MERGE_BLOCKED"
+	ErrCodePRClosed              = "PR_CLOSED"
+	ErrCodePRDraft               = "PR_DRAFT"
+	ErrCodeTransition            = "INVALID_TRANSITION"
+	ErrCodeInvalidReviewer       = "INVALID_REVIEWER"
+	ErrCodeAlreadyAssigned       = "ALREADY_ASSIGNED"
+	ErrCodeMinReviewers          = "MIN_REVIEWERS"
+	ErrCodeUnauthorized          = "UNAUTHORIZED"
+	ErrCodeDisabled              = "INTEGRATION_DISABLED"
+	ErrCodeUnknownIdentity       = "UNKNOWN_IDENTITY"
+	ErrCodeIdempotencyReused     = "IDEMPOTENCY_KEY_REUSED"
+	ErrCodeIdempotencyInProgress = "IDEMPOTENCY_IN_PROGRESS"
+	ErrCodeConcurrentUpdate      = "CONCURRENT_MODIFICATION"
+	ErrCodeTooLarge              = "PAYLOAD_TOO_LARGE"
+	ErrCodeForbidden             = "FORBIDDEN"
 )
 
 const (
-	ErrMsgInvalidJSON         = "invalid json body"
-	ErrMsgAuthorNotFound      = "author not found"
-	ErrMsgPRNotFound          = "pull request not found"
-	ErrMsgPRMerged            = "cannot reassign on merged PR"
-	ErrMsgPRExists            = "PR id already exists"
-	ErrMsgReviewerNotAssigned = "reviewer is not assigned to this PR"
-	ErrMsgNoCandidate         = "no active replacement candidate in team"
-	ErrMsgTeamExists          = "team already exists"
-	ErrMsgMissingTeamName     = "missing team_name"
-	ErrMsgTeamNotFound        = "team not found"
-	ErrMsgMissingUserID       = "missing user_id"
-	ErrMsgUserNotFound        = "user not found"
+	ErrMsgInvalidJSON           = "invalid json body"
+	ErrMsgInvalidBody           = "invalid request body"
+	ErrMsgAuthorNotFound        = "author not found"
+	ErrMsgPRNotFound            = "pull request not found"
+	ErrMsgPRMerged              = "cannot reassign on merged PR"
+	ErrMsgPRExists              = "PR id already exists"
+	ErrMsgReviewerNotAssigned   = "reviewer is not assigned to this PR"
+	ErrMsgNoCandidate           = "no active replacement candidate in team"
+	ErrMsgTeamExists            = "team already exists"
+	ErrMsgMissingTeamName       = "missing team_name"
+	ErrMsgTeamNotFound          = "team not found"
+	ErrMsgMissingUserID         = "missing user_id"
+	ErrMsgMissingPRID           = "missing pull_request_id"
+	ErrMsgUserNotFound          = "user not found"
+	ErrMsgUnknownStrategy       = "unknown reviewer_strategy"
+	ErrMsgInvalidReviewLimit    = "max_open_reviews must not be negative"
+	ErrMsgReviewLimitReached    = "all candidates in team reached the open review limit"
+	ErrMsgInvalidReviewCount    = "required_reviewers must be positive"
+	ErrMsgInvalidPeriod         = "ends_at must be after starts_at"
+	ErrMsgUnknownReason         = "reason must be one of VACATION, SICK_LEAVE, OTHER"
+	ErrMsgPeriodNotFound        = "unavailability period not found"
+	ErrMsgInvalidReviewState    = "state must be one of APPROVED, CHANGES_REQUESTED"
+	ErrMsgReviewMergedPR        = "cannot review merged PR"
+	ErrMsgInvalidApprovals      = "min_approvals must be between 0 and required_reviewers"
+	ErrMsgMissingForcedBy       = "forced_by is required for a forced merge"
+	ErrMsgOverrideForbidden     = "forced_by is not allowed to force a merge"
+	ErrMsgPRClosed              = "PR is closed"
+	ErrMsgPRDraft               = "PR is a draft"
+	ErrMsgCloseMergedPR         = "cannot close merged PR"
+	ErrMsgPRMergedReopen        = "cannot reopen merged PR"
+	ErrMsgNotDraft              = "PR is not a draft"
+	ErrMsgReadyMergedPR         = "cannot mark merged PR as ready"
+	ErrMsgChangeMergedPR        = "cannot change reviewers on merged PR"
+	ErrMsgAlreadyAssigned       = "reviewer is already assigned to this PR"
+	ErrMsgReviewerLimitReached  = "reviewer reached the open review limit"
+	ErrMsgInvalidSLA            = "review_sla_hours and escalation_hours must not be negative"
+	ErrMsgInvalidWebhookURL     = "url must be an absolute http(s) url"
+	ErrMsgMissingSecret         = "missing secret"
+	ErrMsgSubscriptionNotFound  = "webhook subscription not found"
+	ErrMsgInvalidSubscription   = "subscription_id must be a number"
+	ErrMsgInvalidSignature      = "invalid webhook signature"
+	ErrMsgIntegrationDisabled   = "integration is not configured"
+	ErrMsgUnknownProvider       = "provider must be one of github, gitlab"
+	ErrMsgMissingLogin          = "missing login"
+	ErrMsgReadBody              = "cannot read request body"
+	ErrMsgIdempotencyKeyTooLong = "Idempotency-Key must be at most 255 characters"
+	ErrMsgIdempotencyReused     = "Idempotency-Key was already used with a different request"
+	ErrMsgIdempotencyInProgress = "a request with this Idempotency-Key is still in progress"
+	ErrMsgConcurrentUpdate      = "pull request was modified by another request, retry"
+	ErrMsgRulesTooLarge         = "ownership rules must be at most 1 MiB"
 )
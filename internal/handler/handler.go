@@ -5,39 +5,84 @@ import (
 	"log"
 	"net/http"
 
+	"ReviewerAssignmentService/internal/domains"
 	"ReviewerAssignmentService/internal/service"
 )
 
+// ActorHeader names the caller on whose behalf a request is made; it is recorded in PR history.
+const ActorHeader = "X-Actor-ID"
+
 type Handler struct {
-	teamService service.TeamService
-	userService service.UserService
-	prService   service.PRService
+	teamService        service.TeamService
+	userService        service.UserService
+	prService          service.PRService
+	webhookService     service.WebhookService
+	integrationService service.IntegrationService
+	idempotencyService service.IdempotencyService
 }
 
-func New(team service.TeamService, user service.UserService, pr service.PRService) *Handler {
+func New(team service.TeamService, user service.UserService, pr service.PRService,
+	webhook service.WebhookService, integration service.IntegrationService,
+	idempotency service.IdempotencyService) *Handler {
 	return &Handler{
-		teamService: team,
-		userService: user,
-		prService:   pr,
+		teamService:        team,
+		userService:        user,
+		prService:          pr,
+		webhookService:     webhook,
+		integrationService: integration,
+		idempotencyService: idempotency,
 	}
 }
 
-func (h *Handler) InitRoutes() *http.ServeMux {
+func (h *Handler) InitRoutes() http.Handler {
 	mux := http.NewServeMux()
 
 	mux.HandleFunc("POST /team/add", h.createTeam)
 	mux.HandleFunc("GET /team/get", h.getTeam)
+	mux.HandleFunc("POST /team/codeowners", h.setOwnershipRules)
+	mux.HandleFunc("GET /team/codeowners", h.getOwnershipRules)
+	mux.HandleFunc("POST /team/deactivate", h.deactivateTeam)
 
 	mux.HandleFunc("POST /users/setIsActive", h.setUserActive)
 	mux.HandleFunc("GET /users/getReview", h.getUserReviews)
+	mux.HandleFunc("POST /users/addUnavailability", h.addUnavailability)
+	mux.HandleFunc("GET /users/getUnavailability", h.getUnavailability)
+	mux.HandleFunc("POST /users/deleteUnavailability", h.deleteUnavailability)
 
-	mux.HandleFunc("POST /pullRequest/create", h.createPR)
+	mux.HandleFunc("POST /pullRequest/create", h.idempotent(h.createPR))
 	mux.HandleFunc("POST /pullRequest/merge", h.mergePR)
-	mux.HandleFunc("POST /pullRequest/reassign", h.reassignReviewer)
+	mux.HandleFunc("POST /pullRequest/ready", h.markReady)
+	mux.HandleFunc("POST /pullRequest/close", h.closePR)
+	mux.HandleFunc("POST /pullRequest/reopen", h.reopenPR)
+	mux.HandleFunc("POST /pullRequest/reassign", h.idempotent(h.reassignReviewer))
+	mux.HandleFunc("POST /pullRequest/addReviewer", h.addReviewer)
+	mux.HandleFunc("POST /pullRequest/removeReviewer", h.removeReviewer)
+	mux.HandleFunc("POST /pullRequest/review", h.submitReview)
+	mux.HandleFunc("GET /pullRequest/history", h.getPRHistory)
+	mux.HandleFunc("GET /pullRequest/overdue", h.getOverdueReviews)
+
+	mux.HandleFunc("POST /webhooks/add", h.addWebhook)
+	mux.HandleFunc("GET /webhooks/get", h.getWebhooks)
+	mux.HandleFunc("POST /webhooks/delete", h.deleteWebhook)
+	mux.HandleFunc("GET /webhooks/deliveries", h.getWebhookDeliveries)
+
+	mux.HandleFunc("POST /integrations/github", h.handleGitHub)
+	mux.HandleFunc("POST /integrations/gitlab", h.handleGitLab)
+	mux.HandleFunc("POST /integrations/addIdentity", h.addIdentity)
+	mux.HandleFunc("GET /integrations/getIdentities", h.getIdentities)
 
 	mux.HandleFunc("GET /stats", h.getStats)
 
-	return mux
+	return withActor(mux)
+}
+
+func withActor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if actor := r.Header.Get(ActorHeader); actor != "" {
+			r = r.WithContext(domains.WithActor(r.Context(), actor))
+		}
+		next.ServeHTTP(w, r)
+	})
 }
 
 type errorResponse struct {
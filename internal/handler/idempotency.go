@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"ReviewerAssignmentService/internal/service"
+)
+
+const (
+	// IdempotencyKeyHeader lets clients retry a request without repeating its effect.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayHeader marks a response replayed from an earlier request.
+	IdempotentReplayHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+type capturingWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (c *capturingWriter) WriteHeader(status int) {
+	c.status = status
+	c.ResponseWriter.WriteHeader(status)
+}
+
+func (c *capturingWriter) Write(p []byte) (int, error) {
+	c.body.Write(p)
+	return c.ResponseWriter.Write(p)
+}
+
+// retryable reports responses that must not be replayed because repeating the
+// request may succeed: server errors and lost races with a concurrent change.
+func (c *capturingWriter) retryable() bool {
+	if c.status >= http.StatusInternalServerError {
+		return true
+	}
+	if c.status != http.StatusConflict {
+		return false
+	}
+	var resp errorResponse
+	return json.Unmarshal(c.body.Bytes(), &resp) == nil && resp.Error.Code == ErrCodeConcurrentUpdate
+}
+
+// idempotent stores the first response to a request carrying an Idempotency-Key
+// and replays it verbatim for retries with the same key and body. Server errors
+// and concurrent modification conflicts are not stored, so such requests can be
+// retried for real.
+func (h *Handler) idempotent(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if key == "" {
+			next(w, r)
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgIdempotencyKeyTooLong)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgReadBody)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		sum := sha256.Sum256(append([]byte(r.Method+" "+r.URL.Path+"\n"), body...))
+		endpoint := r.URL.Path
+
+		stored, err := h.idempotencyService.Begin(r.Context(), key, endpoint, hex.EncodeToString(sum[:]))
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrIdempotencyKeyReused):
+				writeError(w, http.StatusUnprocessableEntity, ErrCodeIdempotencyReused, ErrMsgIdempotencyReused)
+			case errors.Is(err, service.ErrIdempotencyInProgress):
+				writeError(w, http.StatusConflict, ErrCodeIdempotencyInProgress, ErrMsgIdempotencyInProgress)
+			default:
+				writeError(w, http.StatusInternalServerError, ErrCodeInternalError, err.Error())
+			}
+			return
+		}
+		if stored != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set(IdempotentReplayHeader, "true")
+			w.WriteHeader(stored.StatusCode)
+			if _, err := w.Write(stored.Body); err != nil {
+				log.Printf("Failed to replay response: %v", err)
+			}
+			return
+		}
+
+		capture := &capturingWriter{ResponseWriter: w, status: http.StatusOK}
+		next(capture, r)
+
+		ctx := context.WithoutCancel(r.Context())
+		if capture.retryable() {
+			err = h.idempotencyService.Release(ctx, key, endpoint)
+		} else {
+			err = h.idempotencyService.Complete(ctx, key, endpoint, capture.status, capture.body.Bytes())
+		}
+		if err != nil {
+			log.Printf("Failed to store idempotent response: %v", err)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"ReviewerAssignmentService/internal/domains"
+	"ReviewerAssignmentService/internal/integration"
+	"ReviewerAssignmentService/internal/service"
+)
+
+const maxWebhookBody = 5 << 20
+
+func (h *Handler) handleGitHub(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgReadBody)
+		return
+	}
+
+	result, err := h.integrationService.HandleGitHub(r.Context(),
+		r.Header.Get(integration.GitHubEventHeader), r.Header.Get(integration.GitHubSignatureHeader), body)
+	writeIntegrationResult(w, result, err)
+}
+
+func (h *Handler) handleGitLab(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgReadBody)
+		return
+	}
+
+	result, err := h.integrationService.HandleGitLab(r.Context(),
+		r.Header.Get(integration.GitLabEventHeader), r.Header.Get(integration.GitLabTokenHeader), body)
+	writeIntegrationResult(w, result, err)
+}
+
+func writeIntegrationResult(w http.ResponseWriter, result *domains.IntegrationResult, err error) {
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrIntegrationDisabled):
+			writeError(w, http.StatusServiceUnavailable, ErrCodeDisabled, ErrMsgIntegrationDisabled)
+		case errors.Is(err, service.ErrInvalidSignature):
+			writeError(w, http.StatusUnauthorized, ErrCodeUnauthorized, ErrMsgInvalidSignature)
+		case errors.Is(err, integration.ErrInvalidPayload):
+			writeError(w, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+		case errors.Is(err, service.ErrIdentityNotFound):
+			writeError(w, http.StatusUnprocessableEntity, ErrCodeUnknownIdentity, err.Error())
+		case errors.Is(err, service.ErrAuthorNotFound):
+			writeError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgAuthorNotFound)
+		case errors.Is(err, service.ErrPRNotFound):
+			writeError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgPRNotFound)
+		case errors.Is(err, service.ErrInvalidTransition):
+			writeError(w, http.StatusConflict, ErrCodeTransition, err.Error())
+		case errors.Is(err, service.ErrConcurrentModification):
+			writeError(w, http.StatusConflict, ErrCodeConcurrentUpdate, ErrMsgConcurrentUpdate)
+		default:
+			writeError(w, http.StatusInternalServerError, ErrCodeInternalError, err.Error())
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) addIdentity(w http.ResponseWriter, r *http.Request) {
+	var req domains.ExternalIdentity
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgInvalidJSON)
+		return
+	}
+
+	identity, err := h.integrationService.AddIdentity(r.Context(), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUnknownProvider):
+			writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgUnknownProvider)
+		case errors.Is(err, service.ErrMissingLogin):
+			writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgMissingLogin)
+		case errors.Is(err, service.ErrUserFound):
+			writeError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgUserNotFound)
+		default:
+			writeError(w, http.StatusInternalServerError, ErrCodeInternalError, err.Error())
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"identity": identity,
+	})
+}
+
+func (h *Handler) getIdentities(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgMissingUserID)
+		return
+	}
+
+	identities, err := h.integrationService.GetIdentities(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, service.ErrUserFound) {
+			writeError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgUserNotFound)
+			return
+		}
+		writeError(w, http.StatusInternalServerError, ErrCodeInternalError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"user_id":    userID,
+		"identities": identities,
+	})
+}
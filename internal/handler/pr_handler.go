@@ -4,27 +4,50 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
-	"strings"
 
 	"ReviewerAssignmentService/internal/domains"
 	"ReviewerAssignmentService/internal/service"
 )
 
-const ErrDuplicateKeyValue = "duplicate key value"
-
 type createPRRequest struct {
-	ID       string `json:"pull_request_id"`
-	Name     string `json:"pull_request_name"`
-	AuthorID string `json:"author_id"`
+	ID           string   `json:"pull_request_id"`
+	Name         string   `json:"pull_request_name"`
+	AuthorID     string   `json:"author_id"`
+	ChangedFiles []string `json:"changed_files"`
+	Draft        bool     `json:"draft"`
+}
+
+type readyRequest struct {
+	ID           string   `json:"pull_request_id"`
+	ChangedFiles []string `json:"changed_files"`
 }
 
 type prIDRequest struct {
 	ID string `json:"pull_request_id"`
 }
 
+type mergeRequest struct {
+	ID       string `json:"pull_request_id"`
+	Force    bool   `json:"force"`
+	ForcedBy string `json:"forced_by"`
+	Reason   string `json:"reason"`
+}
+
+type reviewRequest struct {
+	ID         string              `json:"pull_request_id"`
+	ReviewerID string              `json:"reviewer_id"`
+	State      domains.ReviewState `json:"state"`
+}
+
+type reviewerRequest struct {
+	ID         string `json:"pull_request_id"`
+	ReviewerID string `json:"reviewer_id"`
+}
+
 type reassignRequest struct {
 	ID        string `json:"pull_request_id"`
 	OldUserID string `json:"old_user_id"`
+	NewUserID string `json:"new_user_id"`
 }
 
 func (h *Handler) createPR(w http.ResponseWriter, r *http.Request) {
@@ -35,9 +58,11 @@ func (h *Handler) createPR(w http.ResponseWriter, r *http.Request) {
 	}
 
 	input := domains.PullRequestInput{
-		ID:       req.ID,
-		Name:     req.Name,
-		AuthorID: req.AuthorID,
+		ID:           req.ID,
+		Name:         req.Name,
+		AuthorID:     req.AuthorID,
+		ChangedFiles: req.ChangedFiles,
+		Draft:        req.Draft,
 	}
 
 	pr, err := h.prService.CreatePR(r.Context(), input)
@@ -47,7 +72,7 @@ func (h *Handler) createPR(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		if strings.Contains(err.Error(), ErrDuplicateKeyValue) {
+		if errors.Is(err, service.ErrPRExists) {
 			writeError(w, http.StatusConflict, ErrCodePRExists, ErrMsgPRExists)
 			return
 		}
@@ -61,19 +86,139 @@ func (h *Handler) createPR(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) mergePR(w http.ResponseWriter, r *http.Request) {
+	var req mergeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgInvalidJSON)
+		return
+	}
+
+	var (
+		pr       *domains.PullRequest
+		override *domains.MergeOverride
+		err      error
+	)
+	if req.Force {
+		override = &domains.MergeOverride{ForcedBy: req.ForcedBy, Reason: req.Reason}
+		pr, err = h.prService.ForceMergePR(r.Context(), req.ID, override)
+	} else {
+		pr, err = h.prService.MergePR(r.Context(), req.ID)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrPRNotFound):
+			writeError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgPRNotFound)
+		case errors.Is(err, service.ErrMergeBlocked):
+			writeError(w, http.StatusConflict, ErrCodeMergeBlocked, err.Error())
+		case errors.Is(err, service.ErrPRClosed):
+			writeError(w, http.StatusConflict, ErrCodePRClosed, ErrMsgPRClosed)
+		case errors.Is(err, service.ErrPRDraft):
+			writeError(w, http.StatusConflict, ErrCodePRDraft, ErrMsgPRDraft)
+		case errors.Is(err, service.ErrOverrideActorRequired):
+			writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgMissingForcedBy)
+		case errors.Is(err, service.ErrOverrideForbidden):
+			writeError(w, http.StatusForbidden, ErrCodeForbidden, ErrMsgOverrideForbidden)
+		case errors.Is(err, service.ErrConcurrentModification):
+			writeError(w, http.StatusConflict, ErrCodeConcurrentUpdate, ErrMsgConcurrentUpdate)
+		default:
+			writeError(w, http.StatusInternalServerError, ErrCodeInternalError, err.Error())
+		}
+		return
+	}
+
+	response := map[string]interface{}{
+		"pr": pr,
+	}
+	if override != nil && override.PullRequestID != "" {
+		response["override"] = override
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *Handler) markReady(w http.ResponseWriter, r *http.Request) {
+	var req readyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgInvalidJSON)
+		return
+	}
+
+	pr, err := h.prService.MarkReady(r.Context(), req.ID, req.ChangedFiles)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrPRNotFound):
+			writeError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgPRNotFound)
+		case errors.Is(err, service.ErrAuthorNotFound):
+			writeError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgAuthorNotFound)
+		case errors.Is(err, service.ErrPRMerged):
+			writeError(w, http.StatusConflict, ErrCodePRMerged, ErrMsgReadyMergedPR)
+		case errors.Is(err, service.ErrPRClosed):
+			writeError(w, http.StatusConflict, ErrCodePRClosed, ErrMsgPRClosed)
+		case errors.Is(err, service.ErrInvalidTransition):
+			writeError(w, http.StatusConflict, ErrCodeTransition, ErrMsgNotDraft)
+		case errors.Is(err, service.ErrConcurrentModification):
+			writeError(w, http.StatusConflict, ErrCodeConcurrentUpdate, ErrMsgConcurrentUpdate)
+		default:
+			writeError(w, http.StatusInternalServerError, ErrCodeInternalError, err.Error())
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"pr": pr,
+	})
+}
+
+func (h *Handler) closePR(w http.ResponseWriter, r *http.Request) {
 	var req prIDRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgInvalidJSON)
 		return
 	}
 
-	pr, err := h.prService.MergePR(r.Context(), req.ID)
+	pr, err := h.prService.ClosePR(r.Context(), req.ID)
 	if err != nil {
-		if errors.Is(err, service.ErrPRNotFound) {
+		switch {
+		case errors.Is(err, service.ErrPRNotFound):
 			writeError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgPRNotFound)
-			return
+		case errors.Is(err, service.ErrPRMerged):
+			writeError(w, http.StatusConflict, ErrCodePRMerged, ErrMsgCloseMergedPR)
+		case errors.Is(err, service.ErrConcurrentModification):
+			writeError(w, http.StatusConflict, ErrCodeConcurrentUpdate, ErrMsgConcurrentUpdate)
+		default:
+			writeError(w, http.StatusInternalServerError, ErrCodeInternalError, err.Error())
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"pr": pr,
+	})
+}
+
+func (h *Handler) reopenPR(w http.ResponseWriter, r *http.Request) {
+	var req prIDRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgInvalidJSON)
+		return
+	}
+
+	pr, err := h.prService.ReopenPR(r.Context(), req.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrPRNotFound):
+			writeError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgPRNotFound)
+		case errors.Is(err, service.ErrAuthorNotFound):
+			writeError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgAuthorNotFound)
+		case errors.Is(err, service.ErrPRMerged):
+			writeError(w, http.StatusConflict, ErrCodePRMerged, ErrMsgPRMergedReopen)
+		case errors.Is(err, service.ErrPRDraft):
+			writeError(w, http.StatusConflict, ErrCodePRDraft, ErrMsgPRDraft)
+		case errors.Is(err, service.ErrInvalidTransition):
+			writeError(w, http.StatusConflict, ErrCodeTransition, err.Error())
+		case errors.Is(err, service.ErrConcurrentModification):
+			writeError(w, http.StatusConflict, ErrCodeConcurrentUpdate, ErrMsgConcurrentUpdate)
+		default:
+			writeError(w, http.StatusInternalServerError, ErrCodeInternalError, err.Error())
 		}
-		writeError(w, http.StatusInternalServerError, ErrCodeInternalError, err.Error())
 		return
 	}
 
@@ -89,17 +234,37 @@ func (h *Handler) reassignReviewer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pr, newID, err := h.prService.UpdateReviewer(r.Context(), req.ID, req.OldUserID)
+	pr, newID, err := h.prService.UpdateReviewer(r.Context(), req.ID, req.OldUserID, req.NewUserID)
 	if err != nil {
 		switch {
 		case errors.Is(err, service.ErrPRNotFound):
 			writeError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgPRNotFound)
 		case errors.Is(err, service.ErrPRMerged):
 			writeError(w, http.StatusConflict, ErrCodePRMerged, ErrMsgPRMerged)
+		case errors.Is(err, service.ErrPRClosed):
+			writeError(w, http.StatusConflict, ErrCodePRClosed, ErrMsgPRClosed)
+		case errors.Is(err, service.ErrPRDraft):
+			writeError(w, http.StatusConflict, ErrCodePRDraft, ErrMsgPRDraft)
 		case errors.Is(err, service.ErrReviewerNotAssigned):
 			writeError(w, http.StatusConflict, ErrCodeNotAssigned, ErrMsgReviewerNotAssigned)
 		case errors.Is(err, service.ErrNoCandidates):
 			writeError(w, http.StatusConflict, ErrCodeNoCandidate, ErrMsgNoCandidate)
+		case errors.Is(err, service.ErrUserFound):
+			writeError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgUserNotFound)
+		case errors.Is(err, service.ErrReviewerIsAuthor),
+			errors.Is(err, service.ErrNotTeammate),
+			errors.Is(err, service.ErrReviewerUnavailable):
+			writeError(w, http.StatusBadRequest, ErrCodeInvalidReviewer, err.Error())
+		case errors.Is(err, service.ErrAlreadyAssigned):
+			writeError(w, http.StatusConflict, ErrCodeAlreadyAssigned, ErrMsgAlreadyAssigned)
+		case errors.Is(err, service.ErrReviewLimitReached):
+			if req.NewUserID != "" {
+				writeError(w, http.StatusConflict, ErrCodeNoCandidate, ErrMsgReviewerLimitReached)
+				return
+			}
+			writeError(w, http.StatusConflict, ErrCodeNoCandidate, ErrMsgReviewLimitReached)
+		case errors.Is(err, service.ErrConcurrentModification):
+			writeError(w, http.StatusConflict, ErrCodeConcurrentUpdate, ErrMsgConcurrentUpdate)
 		default:
 			writeError(w, http.StatusInternalServerError, ErrCodeInternalError, err.Error())
 		}
@@ -111,3 +276,161 @@ func (h *Handler) reassignReviewer(w http.ResponseWriter, r *http.Request) {
 		"replaced_by": newID,
 	})
 }
+
+func (h *Handler) submitReview(w http.ResponseWriter, r *http.Request) {
+	var req reviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgInvalidJSON)
+		return
+	}
+
+	pr, err := h.prService.SubmitReview(r.Context(), req.ID, req.ReviewerID, req.State)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidReviewState):
+			writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgInvalidReviewState)
+		case errors.Is(err, service.ErrPRNotFound):
+			writeError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgPRNotFound)
+		case errors.Is(err, service.ErrPRMerged):
+			writeError(w, http.StatusConflict, ErrCodePRMerged, ErrMsgReviewMergedPR)
+		case errors.Is(err, service.ErrPRClosed):
+			writeError(w, http.StatusConflict, ErrCodePRClosed, ErrMsgPRClosed)
+		case errors.Is(err, service.ErrPRDraft):
+			writeError(w, http.StatusConflict, ErrCodePRDraft, ErrMsgPRDraft)
+		case errors.Is(err, service.ErrReviewerNotAssigned):
+			writeError(w, http.StatusConflict, ErrCodeNotAssigned, ErrMsgReviewerNotAssigned)
+		case errors.Is(err, service.ErrConcurrentModification):
+			writeError(w, http.StatusConflict, ErrCodeConcurrentUpdate, ErrMsgConcurrentUpdate)
+		default:
+			writeError(w, http.StatusInternalServerError, ErrCodeInternalError, err.Error())
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"pr": pr,
+	})
+}
+
+func (h *Handler) addReviewer(w http.ResponseWriter, r *http.Request) {
+	var req reviewerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgInvalidJSON)
+		return
+	}
+
+	pr, err := h.prService.AddReviewer(r.Context(), req.ID, req.ReviewerID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrPRNotFound):
+			writeError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgPRNotFound)
+		case errors.Is(err, service.ErrUserFound):
+			writeError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgUserNotFound)
+		case errors.Is(err, service.ErrPRMerged):
+			writeError(w, http.StatusConflict, ErrCodePRMerged, ErrMsgChangeMergedPR)
+		case errors.Is(err, service.ErrPRClosed):
+			writeError(w, http.StatusConflict, ErrCodePRClosed, ErrMsgPRClosed)
+		case errors.Is(err, service.ErrPRDraft):
+			writeError(w, http.StatusConflict, ErrCodePRDraft, ErrMsgPRDraft)
+		case errors.Is(err, service.ErrReviewerIsAuthor),
+			errors.Is(err, service.ErrNotTeammate),
+			errors.Is(err, service.ErrReviewerUnavailable):
+			writeError(w, http.StatusBadRequest, ErrCodeInvalidReviewer, err.Error())
+		case errors.Is(err, service.ErrAlreadyAssigned):
+			writeError(w, http.StatusConflict, ErrCodeAlreadyAssigned, ErrMsgAlreadyAssigned)
+		case errors.Is(err, service.ErrReviewLimitReached):
+			writeError(w, http.StatusConflict, ErrCodeNoCandidate, ErrMsgReviewerLimitReached)
+		case errors.Is(err, service.ErrConcurrentModification):
+			writeError(w, http.StatusConflict, ErrCodeConcurrentUpdate, ErrMsgConcurrentUpdate)
+		default:
+			writeError(w, http.StatusInternalServerError, ErrCodeInternalError, err.Error())
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"pr": pr,
+	})
+}
+
+func (h *Handler) removeReviewer(w http.ResponseWriter, r *http.Request) {
+	var req reviewerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgInvalidJSON)
+		return
+	}
+
+	pr, err := h.prService.RemoveReviewer(r.Context(), req.ID, req.ReviewerID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrPRNotFound):
+			writeError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgPRNotFound)
+		case errors.Is(err, service.ErrPRMerged):
+			writeError(w, http.StatusConflict, ErrCodePRMerged, ErrMsgChangeMergedPR)
+		case errors.Is(err, service.ErrPRClosed):
+			writeError(w, http.StatusConflict, ErrCodePRClosed, ErrMsgPRClosed)
+		case errors.Is(err, service.ErrPRDraft):
+			writeError(w, http.StatusConflict, ErrCodePRDraft, ErrMsgPRDraft)
+		case errors.Is(err, service.ErrReviewerNotAssigned):
+			writeError(w, http.StatusConflict, ErrCodeNotAssigned, ErrMsgReviewerNotAssigned)
+		case errors.Is(err, service.ErrMinReviewers):
+			writeError(w, http.StatusConflict, ErrCodeMinReviewers, err.Error())
+		case errors.Is(err, service.ErrConcurrentModification):
+			writeError(w, http.StatusConflict, ErrCodeConcurrentUpdate, ErrMsgConcurrentUpdate)
+		default:
+			writeError(w, http.StatusInternalServerError, ErrCodeInternalError, err.Error())
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"pr": pr,
+	})
+}
+
+func (h *Handler) getPRHistory(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgMissingPRID)
+		return
+	}
+
+	events, err := h.prService.GetHistory(r.Context(), prID)
+	if err != nil {
+		if errors.Is(err, service.ErrPRNotFound) {
+			writeError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgPRNotFound)
+			return
+		}
+		writeError(w, http.StatusInternalServerError, ErrCodeInternalError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"pull_request_id": prID,
+		"events":          events,
+	})
+}
+
+func (h *Handler) getOverdueReviews(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	teamName := query.Get("team_name")
+	if query.Has("team_name") && teamName == "" {
+		writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgMissingTeamName)
+		return
+	}
+
+	reviews, err := h.prService.GetOverdueReviews(r.Context(), teamName)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrTeamNotFound):
+			writeError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgTeamNotFound)
+		default:
+			writeError(w, http.StatusInternalServerError, ErrCodeInternalError, err.Error())
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"overdue": reviews,
+	})
+}
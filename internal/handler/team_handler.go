@@ -2,24 +2,69 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 
+	"ReviewerAssignmentService/internal/codeowners"
 	"ReviewerAssignmentService/internal/domains"
+	"ReviewerAssignmentService/internal/service"
 )
 
+const maxOwnershipRulesSize = 1 << 20
+
+// createTeamRequest tells an omitted required_reviewers, which takes the default, from an explicit 0.
+type createTeamRequest struct {
+	domains.Team
+	RequiredReviewers *int `json:"required_reviewers"`
+}
+
 func (h *Handler) createTeam(w http.ResponseWriter, r *http.Request) {
-	var team domains.Team
-	if err := json.NewDecoder(r.Body).Decode(&team); err != nil {
+	var req createTeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgInvalidJSON)
 		return
 	}
 
+	team := req.Team
+	if req.RequiredReviewers != nil {
+		if *req.RequiredReviewers <= 0 {
+			writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgInvalidReviewCount)
+			return
+		}
+		team.RequiredReviewers = *req.RequiredReviewers
+	}
+
 	createdTeam, err := h.teamService.CreateTeam(r.Context(), &team)
 	if err != nil {
 		if err.Error() == ErrMsgTeamExists {
 			writeError(w, http.StatusBadRequest, ErrCodeTeamExists, ErrMsgTeamExists)
 			return
 		}
+		if errors.Is(err, service.ErrUnknownStrategy) {
+			writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgUnknownStrategy)
+			return
+		}
+		if errors.Is(err, service.ErrInvalidReviewLimit) {
+			writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgInvalidReviewLimit)
+			return
+		}
+		if errors.Is(err, service.ErrInvalidReviewCount) {
+			writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgInvalidReviewCount)
+			return
+		}
+		if errors.Is(err, service.ErrInvalidApprovals) {
+			writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgInvalidApprovals)
+			return
+		}
+		if errors.Is(err, service.ErrInvalidSLA) {
+			writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgInvalidSLA)
+			return
+		}
+		if errors.Is(err, service.ErrInvalidFallback) {
+			writeError(w, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+			return
+		}
 		writeError(w, http.StatusInternalServerError, ErrCodeInternalError, err.Error())
 		return
 	}
@@ -48,3 +93,93 @@ func (h *Handler) getTeam(w http.ResponseWriter, r *http.Request) {
 
 	writeJSON(w, http.StatusOK, team)
 }
+
+func (h *Handler) deactivateTeam(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TeamName string `json:"team_name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgInvalidJSON)
+		return
+	}
+	if req.TeamName == "" {
+		writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgMissingTeamName)
+		return
+	}
+
+	result, err := h.prService.DeactivateTeam(r.Context(), req.TeamName)
+	if err != nil {
+		if errors.Is(err, service.ErrTeamNotFound) {
+			writeError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgTeamNotFound)
+			return
+		}
+		if errors.Is(err, service.ErrConcurrentModification) {
+			writeError(w, http.StatusConflict, ErrCodeConcurrentUpdate, ErrMsgConcurrentUpdate)
+			return
+		}
+		writeError(w, http.StatusInternalServerError, ErrCodeInternalError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) setOwnershipRules(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("team_name")
+	if name == "" {
+		writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgMissingTeamName)
+		return
+	}
+
+	content, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxOwnershipRulesSize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, ErrCodeTooLarge, ErrMsgRulesTooLarge)
+			return
+		}
+		writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgInvalidBody)
+		return
+	}
+
+	rules, err := h.teamService.SetOwnershipRules(r.Context(), name, string(content))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrTeamNotFound):
+			writeError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgTeamNotFound)
+		case errors.Is(err, codeowners.ErrInvalidRule), errors.Is(err, service.ErrUnknownOwner):
+			writeError(w, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+		default:
+			writeError(w, http.StatusInternalServerError, ErrCodeInternalError, err.Error())
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"team_name": name,
+		"rules":     rules,
+	})
+}
+
+func (h *Handler) getOwnershipRules(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("team_name")
+	if name == "" {
+		writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgMissingTeamName)
+		return
+	}
+
+	rules, err := h.teamService.GetOwnershipRules(r.Context(), name)
+	if err != nil {
+		if errors.Is(err, service.ErrTeamNotFound) {
+			writeError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgTeamNotFound)
+			return
+		}
+		writeError(w, http.StatusInternalServerError, ErrCodeInternalError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"team_name": name,
+		"rules":     rules,
+	})
+}
@@ -2,12 +2,21 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+
+	"ReviewerAssignmentService/internal/domains"
+	"ReviewerAssignmentService/internal/service"
 )
 
 type setActiveRequest struct {
-	UserID   string `json:"user_id"`
-	IsActive bool   `json:"is_active"`
+	UserID          string `json:"user_id"`
+	IsActive        bool   `json:"is_active"`
+	ReassignReviews bool   `json:"reassign_reviews"`
+}
+
+type unavailabilityIDRequest struct {
+	ID int64 `json:"id"`
 }
 
 func (h *Handler) setUserActive(w http.ResponseWriter, r *http.Request) {
@@ -17,6 +26,11 @@ func (h *Handler) setUserActive(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !req.IsActive && req.ReassignReviews {
+		h.deactivateWithReassignment(w, r, req.UserID)
+		return
+	}
+
 	err := h.userService.SetIsActive(r.Context(), req.UserID, req.IsActive)
 	if err != nil {
 		if err.Error() == ErrMsgUserNotFound {
@@ -35,6 +49,30 @@ func (h *Handler) setUserActive(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (h *Handler) deactivateWithReassignment(w http.ResponseWriter, r *http.Request, userID string) {
+	report, err := h.prService.DeactivateUser(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, service.ErrUserFound) {
+			writeError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgUserNotFound)
+			return
+		}
+		if errors.Is(err, service.ErrConcurrentModification) {
+			writeError(w, http.StatusConflict, ErrCodeConcurrentUpdate, ErrMsgConcurrentUpdate)
+			return
+		}
+		writeError(w, http.StatusInternalServerError, ErrCodeInternalError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"user": map[string]interface{}{
+			"user_id":   userID,
+			"is_active": false,
+		},
+		"reassignments": report,
+	})
+}
+
 func (h *Handler) getUserReviews(w http.ResponseWriter, r *http.Request) {
 	userID := r.URL.Query().Get("user_id")
 	if userID == "" {
@@ -67,3 +105,75 @@ func (h *Handler) getStats(w http.ResponseWriter, r *http.Request) {
 
 	writeJSON(w, http.StatusOK, stats)
 }
+
+func (h *Handler) addUnavailability(w http.ResponseWriter, r *http.Request) {
+	var req domains.Unavailability
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgInvalidJSON)
+		return
+	}
+
+	period, err := h.userService.AddUnavailability(r.Context(), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUserFound):
+			writeError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgUserNotFound)
+		case errors.Is(err, service.ErrInvalidPeriod):
+			writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgInvalidPeriod)
+		case errors.Is(err, service.ErrUnknownUnavailability):
+			writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgUnknownReason)
+		default:
+			writeError(w, http.StatusInternalServerError, ErrCodeInternalError, err.Error())
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"unavailability": period,
+	})
+}
+
+func (h *Handler) getUnavailability(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgMissingUserID)
+		return
+	}
+
+	periods, err := h.userService.GetUnavailability(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, service.ErrUserFound) {
+			writeError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgUserNotFound)
+			return
+		}
+		writeError(w, http.StatusInternalServerError, ErrCodeInternalError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"user_id":        userID,
+		"unavailability": periods,
+	})
+}
+
+func (h *Handler) deleteUnavailability(w http.ResponseWriter, r *http.Request) {
+	var req unavailabilityIDRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgInvalidJSON)
+		return
+	}
+
+	if err := h.userService.DeleteUnavailability(r.Context(), req.ID); err != nil {
+		if errors.Is(err, service.ErrUnavailabilityNotFound) {
+			writeError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgPeriodNotFound)
+			return
+		}
+		writeError(w, http.StatusInternalServerError, ErrCodeInternalError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":      req.ID,
+		"deleted": true,
+	})
+}
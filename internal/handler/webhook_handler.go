@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ReviewerAssignmentService/internal/domains"
+	"ReviewerAssignmentService/internal/service"
+)
+
+type webhookIDRequest struct {
+	ID int64 `json:"id"`
+}
+
+func (h *Handler) addWebhook(w http.ResponseWriter, r *http.Request) {
+	var req domains.WebhookSubscription
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgInvalidJSON)
+		return
+	}
+
+	sub, err := h.webhookService.CreateSubscription(r.Context(), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidWebhookURL):
+			writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgInvalidWebhookURL)
+		case errors.Is(err, service.ErrMissingWebhookSecret):
+			writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgMissingSecret)
+		case errors.Is(err, service.ErrUnknownWebhookEvent):
+			writeError(w, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+		default:
+			writeError(w, http.StatusInternalServerError, ErrCodeInternalError, err.Error())
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"subscription": sub,
+	})
+}
+
+func (h *Handler) getWebhooks(w http.ResponseWriter, r *http.Request) {
+	subs, err := h.webhookService.GetSubscriptions(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, ErrCodeInternalError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"subscriptions": subs,
+	})
+}
+
+func (h *Handler) deleteWebhook(w http.ResponseWriter, r *http.Request) {
+	var req webhookIDRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgInvalidJSON)
+		return
+	}
+
+	if err := h.webhookService.DeleteSubscription(r.Context(), req.ID); err != nil {
+		if errors.Is(err, service.ErrSubscriptionNotFound) {
+			writeError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgSubscriptionNotFound)
+			return
+		}
+		writeError(w, http.StatusInternalServerError, ErrCodeInternalError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":      req.ID,
+		"deleted": true,
+	})
+}
+
+func (h *Handler) getWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	subscriptionID, err := strconv.ParseInt(r.URL.Query().Get("subscription_id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrCodeBadRequest, ErrMsgInvalidSubscription)
+		return
+	}
+
+	deliveries, err := h.webhookService.GetDeliveries(r.Context(), subscriptionID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, ErrCodeInternalError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"subscription_id": subscriptionID,
+		"deliveries":      deliveries,
+	})
+}
@@ -0,0 +1,166 @@
+// Package integration parses pull/merge request webhooks sent by GitHub and GitLab.
+package integration
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"ReviewerAssignmentService/internal/domains"
+)
+
+const (
+	GitHubEventHeader     = "X-GitHub-Event"
+	GitHubSignatureHeader = "X-Hub-Signature-256"
+	GitLabEventHeader     = "X-Gitlab-Event"
+	GitLabTokenHeader     = "X-Gitlab-Token"
+
+	GitHubPullRequestEvent  = "pull_request"
+	GitLabMergeRequestEvent = "Merge Request Hook"
+
+	signaturePrefix = "sha256="
+)
+
+var ErrInvalidPayload = errors.New("invalid webhook payload")
+
+// VerifyGitHubSignature checks the X-Hub-Signature-256 header against body signed with secret.
+func VerifyGitHubSignature(secret string, body []byte, signature string) bool {
+	if secret == "" || !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+	expected, err := hex.DecodeString(strings.TrimPrefix(signature, signaturePrefix))
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// VerifyGitLabToken checks the X-Gitlab-Token header against the configured token.
+func VerifyGitLabToken(token string, header string) bool {
+	return token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(header)) == 1
+}
+
+type githubPayload struct {
+	Action      string `json:"action"`
+	PullRequest *struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+		Draft  bool   `json:"draft"`
+		Merged bool   `json:"merged"`
+		User   struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	} `json:"pull_request"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	Sender struct {
+		Login string `json:"login"`
+	} `json:"sender"`
+}
+
+// ParseGitHub reads a GitHub pull_request event. PRs are identified as
+// "github:<owner>/<repo>#<number>".
+func ParseGitHub(body []byte) (*domains.ExternalPREvent, error) {
+	var payload githubPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+	if payload.PullRequest == nil || payload.Repository.FullName == "" {
+		return nil, fmt.Errorf("%w: missing pull_request or repository", ErrInvalidPayload)
+	}
+
+	pr := payload.PullRequest
+	event := &domains.ExternalPREvent{
+		Provider:      domains.ProviderGitHub,
+		PullRequestID: fmt.Sprintf("%s:%s#%d", domains.ProviderGitHub, payload.Repository.FullName, pr.Number),
+		Title:         pr.Title,
+		AuthorLogin:   pr.User.Login,
+		SenderLogin:   payload.Sender.Login,
+		Draft:         pr.Draft,
+	}
+
+	switch payload.Action {
+	case "opened":
+		event.Action = domains.ExternalOpened
+	case "ready_for_review":
+		event.Action = domains.ExternalReady
+	case "reopened":
+		event.Action = domains.ExternalReopened
+	case "closed":
+		event.Action = domains.ExternalClosed
+		if pr.Merged {
+			event.Action = domains.ExternalMerged
+		}
+	}
+	return event, nil
+}
+
+type gitlabPayload struct {
+	User struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	ObjectAttributes *struct {
+		IID            int    `json:"iid"`
+		Title          string `json:"title"`
+		Action         string `json:"action"`
+		Draft          bool   `json:"draft"`
+		WorkInProgress bool   `json:"work_in_progress"`
+	} `json:"object_attributes"`
+	Changes struct {
+		Draft *struct {
+			Previous bool `json:"previous"`
+			Current  bool `json:"current"`
+		} `json:"draft"`
+	} `json:"changes"`
+}
+
+// ParseGitLab reads a GitLab merge request event. MRs are identified as
+// "gitlab:<namespace>/<project>!<iid>". GitLab only reports who triggered the
+// event, so the author is taken from the "open" event's user.
+func ParseGitLab(body []byte) (*domains.ExternalPREvent, error) {
+	var payload gitlabPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+	if payload.ObjectAttributes == nil || payload.Project.PathWithNamespace == "" {
+		return nil, fmt.Errorf("%w: missing object_attributes or project", ErrInvalidPayload)
+	}
+
+	mr := payload.ObjectAttributes
+	event := &domains.ExternalPREvent{
+		Provider:      domains.ProviderGitLab,
+		PullRequestID: fmt.Sprintf("%s:%s!%d", domains.ProviderGitLab, payload.Project.PathWithNamespace, mr.IID),
+		Title:         mr.Title,
+		AuthorLogin:   payload.User.Username,
+		SenderLogin:   payload.User.Username,
+		Draft:         mr.Draft || mr.WorkInProgress,
+	}
+
+	switch mr.Action {
+	case "open":
+		event.Action = domains.ExternalOpened
+	case "update":
+		if draft := payload.Changes.Draft; draft != nil && draft.Previous && !draft.Current {
+			event.Action = domains.ExternalReady
+		}
+	case "merge":
+		event.Action = domains.ExternalMerged
+	case "close":
+		event.Action = domains.ExternalClosed
+	case "reopen":
+		event.Action = domains.ExternalReopened
+	}
+	return event, nil
+}
@@ -0,0 +1,10 @@
+package repository
+
+import "errors"
+
+var (
+	// ErrAlreadyExists is returned when an insert hits a unique constraint.
+	ErrAlreadyExists = errors.New("already exists")
+	// ErrConflict is returned when a pull request changed after it was read.
+	ErrConflict = errors.New("concurrent modification")
+)
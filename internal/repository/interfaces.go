@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"ReviewerAssignmentService/internal/domains"
 )
@@ -10,16 +11,27 @@ type UserRepository interface {
 	Create(ctx context.Context, user *domains.User) error
 	Exists(ctx context.Context, userName string) (bool, error)
 	GetByID(ctx context.Context, id string) (*domains.User, error)
-	GetRandomActiveUsersByTeam(ctx context.Context, teamName string, excludeUserID string, limit int) ([]string, error)
+	GetActiveCandidatesByTeam(ctx context.Context, teamName string, excludeUserIDs []string) ([]domains.ReviewCandidate, error)
+	GetActiveCandidatesByIDs(ctx context.Context, userIDs []string, excludeUserIDs []string) ([]domains.ReviewCandidate, error)
 	UpdateActivity(ctx context.Context, userID string, isActive bool) error
-	DeactivateTeamMembers(ctx context.Context, teamName string) error
+	DeactivateTeamMembers(ctx context.Context, teamName string, reassigned []*domains.PullRequest) error
 	Count(ctx context.Context) (int, error)
+	AddUnavailability(ctx context.Context, period *domains.Unavailability) error
+	GetUnavailability(ctx context.Context, userID string) ([]domains.Unavailability, error)
+	DeleteUnavailability(ctx context.Context, id int64) (bool, error)
+	DeactivateUsers(ctx context.Context, userIDs []string, reassigned []*domains.PullRequest) error
 }
 
 type TeamRepository interface {
 	Create(ctx context.Context, team *domains.Team) error
 	Exists(ctx context.Context, teamName string) (bool, error)
 	GetByName(ctx context.Context, teamName string) (*domains.Team, error)
+	GetSettings(ctx context.Context, teamName string) (*domains.TeamSettings, error)
+}
+
+type OwnershipRepository interface {
+	ReplaceRules(ctx context.Context, teamName string, rules []domains.OwnershipRule) error
+	GetRules(ctx context.Context, teamName string) ([]domains.OwnershipRule, error)
 }
 
 type PRRepository interface {
@@ -27,6 +39,55 @@ type PRRepository interface {
 	Exists(ctx context.Context, prName string) (bool, error)
 	GetByID(ctx context.Context, id string) (*domains.PullRequest, error)
 	GetByReviewer(ctx context.Context, reviewerID string) ([]*domains.PullRequestShort, error)
+	GetOpenByReviewers(ctx context.Context, reviewerIDs []string) ([]*domains.PullRequest, error)
 	Update(ctx context.Context, pr *domains.PullRequest) error
+	MergeWithOverride(ctx context.Context, pr *domains.PullRequest, override *domains.MergeOverride) error
+	SetReviewState(ctx context.Context, prID string, reviewerID string, state domains.ReviewState) error
+	GetEvents(ctx context.Context, prID string) ([]domains.PREvent, error)
+	GetPendingReviews(ctx context.Context) ([]domains.PendingReview, error)
+	GetOverdueReviews(ctx context.Context, teamName string) ([]domains.PendingReview, error)
+	MarkOverdue(ctx context.Context, reviews []domains.PendingReview, at time.Time) error
+	GetTimedOutReviewers(ctx context.Context, prID string) ([]string, error)
 	Count(ctx context.Context) (int, error)
 }
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, sub *domains.WebhookSubscription) error
+	GetSubscriptions(ctx context.Context) ([]domains.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id int64) (bool, error)
+	GetEventsAfter(ctx context.Context, afterID int64, types []domains.PREventType, limit int) ([]domains.PREvent, error)
+	QueueDeliveries(ctx context.Context, subscriptionID int64, events []domains.PREvent, at time.Time) error
+	GetDueDeliveries(ctx context.Context, subscriptionID int64, now time.Time, limit int) ([]domains.PendingDelivery, error)
+	RecordDelivery(ctx context.Context, delivery *domains.WebhookDelivery) error
+	GetDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]domains.WebhookDelivery, error)
+}
+
+type IdentityRepository interface {
+	Upsert(ctx context.Context, identity *domains.ExternalIdentity) error
+	GetUserID(ctx context.Context, provider domains.Provider, login string) (string, error)
+	GetByUser(ctx context.Context, userID string) ([]domains.ExternalIdentity, error)
+}
+
+type IdempotencyRepository interface {
+	Reserve(ctx context.Context, record *domains.IdempotentResponse) (bool, error)
+	Get(ctx context.Context, key string, endpoint string) (*domains.IdempotentResponse, error)
+	Complete(ctx context.Context, key string, endpoint string, statusCode int, body []byte, expiresAt time.Time) error
+	Delete(ctx context.Context, key string, endpoint string) error
+}
+
+type Locker interface {
+	TryWithLock(ctx context.Context, key int64, fn func(ctx context.Context) error) (bool, error)
+}
+
+// Repositories are the repositories a UnitOfWork binds to one transaction.
+type Repositories struct {
+	PR        PRRepository
+	User      UserRepository
+	Team      TeamRepository
+	Ownership OwnershipRepository
+}
+
+type UnitOfWork interface {
+	// WithTx runs fn in one transaction, committing when fn returns nil and rolling back otherwise.
+	WithTx(ctx context.Context, fn func(repos Repositories) error) error
+}
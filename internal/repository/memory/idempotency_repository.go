@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"ReviewerAssignmentService/internal/domains"
+)
+
+type idempotencyRepositoryImpl struct {
+	store *Store
+}
+
+func NewIdempotencyRepository(store *Store) *idempotencyRepositoryImpl {
+	return &idempotencyRepositoryImpl{store: store}
+}
+
+// Reserve claims the key for a new request unless an unexpired record for it
+// already exists; it reports whether the key was claimed.
+func (i *idempotencyRepositoryImpl) Reserve(_ context.Context, record *domains.IdempotentResponse) (bool, error) {
+	var reserved bool
+	err := i.store.write(func(d *data) error {
+		key := idempotencyKey{key: record.Key, endpoint: record.Endpoint}
+		if stored, ok := d.idempotency[key]; ok && stored.ExpiresAt.After(time.Now()) {
+			return nil
+		}
+		d.idempotency[key] = domains.IdempotentResponse{
+			Key:         record.Key,
+			Endpoint:    record.Endpoint,
+			RequestHash: record.RequestHash,
+			ExpiresAt:   record.ExpiresAt,
+		}
+		reserved = true
+		return nil
+	})
+	return reserved, err
+}
+
+func (i *idempotencyRepositoryImpl) Get(
+	_ context.Context, key string, endpoint string) (*domains.IdempotentResponse, error) {
+	var result *domains.IdempotentResponse
+	err := i.store.read(func(d *data) error {
+		stored, ok := d.idempotency[idempotencyKey{key: key, endpoint: endpoint}]
+		if ok && stored.ExpiresAt.After(time.Now()) {
+			stored.Body = slices.Clone(stored.Body)
+			result = &stored
+		}
+		return nil
+	})
+	return result, err
+}
+
+func (i *idempotencyRepositoryImpl) Complete(
+	_ context.Context, key string, endpoint string, statusCode int, body []byte, expiresAt time.Time) error {
+	return i.store.write(func(d *data) error {
+		k := idempotencyKey{key: key, endpoint: endpoint}
+		stored, ok := d.idempotency[k]
+		if !ok {
+			return nil
+		}
+		stored.StatusCode = statusCode
+		stored.Body = slices.Clone(body)
+		stored.Completed = true
+		stored.ExpiresAt = expiresAt
+		d.idempotency[k] = stored
+		return nil
+	})
+}
+
+func (i *idempotencyRepositoryImpl) Delete(_ context.Context, key string, endpoint string) error {
+	return i.store.write(func(d *data) error {
+		delete(d.idempotency, idempotencyKey{key: key, endpoint: endpoint})
+		return nil
+	})
+}
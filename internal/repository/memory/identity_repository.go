@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"ReviewerAssignmentService/internal/domains"
+)
+
+type identityRepositoryImpl struct {
+	store *Store
+}
+
+func NewIdentityRepository(store *Store) *identityRepositoryImpl {
+	return &identityRepositoryImpl{store: store}
+}
+
+func (i *identityRepositoryImpl) Upsert(_ context.Context, identity *domains.ExternalIdentity) error {
+	return i.store.write(func(d *data) error {
+		if _, ok := d.users[identity.UserID]; !ok {
+			return errUserNotFound
+		}
+		d.identities[identityKey{provider: identity.Provider, login: identity.Login}] = identity.UserID
+		return nil
+	})
+}
+
+func (i *identityRepositoryImpl) GetUserID(
+	_ context.Context, provider domains.Provider, login string) (string, error) {
+	var userID string
+	err := i.store.read(func(d *data) error {
+		userID = d.identities[identityKey{provider: provider, login: login}]
+		return nil
+	})
+	return userID, err
+}
+
+func (i *identityRepositoryImpl) GetByUser(_ context.Context, userID string) ([]domains.ExternalIdentity, error) {
+	identities := make([]domains.ExternalIdentity, 0)
+	err := i.store.read(func(d *data) error {
+		for key, owner := range d.identities {
+			if owner == userID {
+				identities = append(identities, domains.ExternalIdentity{
+					Provider: key.provider, Login: key.login, UserID: owner,
+				})
+			}
+		}
+		return nil
+	})
+	slices.SortFunc(identities, func(a, b domains.ExternalIdentity) int {
+		return cmp.Or(cmp.Compare(a.Provider, b.Provider), cmp.Compare(a.Login, b.Login))
+	})
+	return identities, err
+}
@@ -0,0 +1,37 @@
+package memory
+
+import (
+	"context"
+	"sync"
+)
+
+// lockerImpl serializes work inside one process; unlike the advisory locker it
+// does not coordinate several replicas.
+type lockerImpl struct {
+	mu     sync.Mutex
+	locked map[int64]bool
+}
+
+func NewLocker() *lockerImpl {
+	return &lockerImpl{locked: make(map[int64]bool)}
+}
+
+// TryWithLock runs fn only if the lock identified by key is free and reports
+// whether it ran.
+func (l *lockerImpl) TryWithLock(ctx context.Context, key int64, fn func(ctx context.Context) error) (bool, error) {
+	l.mu.Lock()
+	if l.locked[key] {
+		l.mu.Unlock()
+		return false, nil
+	}
+	l.locked[key] = true
+	l.mu.Unlock()
+
+	defer func() {
+		l.mu.Lock()
+		delete(l.locked, key)
+		l.mu.Unlock()
+	}()
+
+	return true, fn(ctx)
+}
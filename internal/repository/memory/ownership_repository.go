@@ -0,0 +1,47 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"ReviewerAssignmentService/internal/domains"
+)
+
+type ownershipRepositoryImpl struct {
+	store *Store
+}
+
+func NewOwnershipRepository(store *Store) *ownershipRepositoryImpl {
+	return &ownershipRepositoryImpl{store: store}
+}
+
+func (o *ownershipRepositoryImpl) ReplaceRules(
+	_ context.Context, teamName string, rules []domains.OwnershipRule) error {
+	return o.store.write(func(d *data) error {
+		if _, ok := d.teams[teamName]; !ok {
+			return errTeamNotFound
+		}
+		d.ownership[teamName] = cloneRules(rules)
+		return nil
+	})
+}
+
+func (o *ownershipRepositoryImpl) GetRules(_ context.Context, teamName string) ([]domains.OwnershipRule, error) {
+	var rules []domains.OwnershipRule
+	err := o.store.read(func(d *data) error {
+		rules = cloneRules(d.ownership[teamName])
+		return nil
+	})
+	return rules, err
+}
+
+func cloneRules(rules []domains.OwnershipRule) []domains.OwnershipRule {
+	cloned := make([]domains.OwnershipRule, 0, len(rules))
+	for _, rule := range rules {
+		cloned = append(cloned, domains.OwnershipRule{
+			Pattern: rule.Pattern,
+			Owners:  slices.Clone(nonNilIDs(rule.Owners)),
+		})
+	}
+	return cloned
+}
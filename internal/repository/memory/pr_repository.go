@@ -0,0 +1,503 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"ReviewerAssignmentService/internal/domains"
+	"ReviewerAssignmentService/internal/repository"
+)
+
+var statusEvents = map[domains.PRStatus]domains.PREventType{
+	domains.PRStatusOpen:     domains.PREventReady,
+	domains.PRStatusMerged:   domains.PREventMerged,
+	domains.PRStatusClosed:   domains.PREventClosed,
+	domains.PRStatusReopened: domains.PREventReopened,
+}
+
+type prRepositoryImpl struct {
+	store *Store
+}
+
+func NewPrRepository(store *Store) *prRepositoryImpl {
+	return &prRepositoryImpl{store: store}
+}
+
+func (p *prRepositoryImpl) Create(ctx context.Context, pr *domains.PullRequest) error {
+	return p.store.write(func(d *data) error {
+		if _, exists := d.prs[pr.ID]; exists {
+			return repository.ErrAlreadyExists
+		}
+		if _, ok := d.users[pr.AuthorID]; !ok {
+			return fmt.Errorf("author %s: %w", pr.AuthorID, errUserNotFound)
+		}
+		if err := d.checkReviewers(pr); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		d.lastPRSeq++
+		d.prs[pr.ID] = pullRequest{
+			id:       pr.ID,
+			name:     pr.Name,
+			authorID: pr.AuthorID,
+			status:   pr.Status,
+			files:    slices.Clone(pr.ChangedFiles),
+			seq:      d.lastPRSeq,
+		}
+		d.appendEvent(ctx, domains.PREvent{PullRequestID: pr.ID, Type: domains.PREventCreated}, now)
+		d.syncReviewers(ctx, pr, "", now)
+		return nil
+	})
+}
+
+func (p *prRepositoryImpl) Exists(_ context.Context, prID string) (bool, error) {
+	var exists bool
+	err := p.store.read(func(d *data) error {
+		_, exists = d.prs[prID]
+		return nil
+	})
+	return exists, err
+}
+
+func (p *prRepositoryImpl) GetByID(_ context.Context, id string) (*domains.PullRequest, error) {
+	var result *domains.PullRequest
+	err := p.store.read(func(d *data) error {
+		stored, ok := d.prs[id]
+		if !ok {
+			return nil
+		}
+
+		result = d.pullRequest(stored)
+		result.Reviews = make([]domains.Review, 0)
+		for _, i := range d.active(id) {
+			updatedAt := d.assignments[i].stateUpdatedAt
+			result.Reviews = append(result.Reviews, domains.Review{
+				ReviewerID: d.assignments[i].reviewerID,
+				State:      d.assignments[i].state,
+				UpdatedAt:  &updatedAt,
+			})
+		}
+		return nil
+	})
+	return result, err
+}
+
+func (p *prRepositoryImpl) GetByReviewer(_ context.Context, reviewerID string) ([]*domains.PullRequestShort, error) {
+	var prs []*domains.PullRequestShort
+	err := p.store.read(func(d *data) error {
+		matched := make([]assignment, 0)
+		for _, a := range d.assignments {
+			if a.reviewerID == reviewerID && a.replacedAt == nil {
+				matched = append(matched, a)
+			}
+		}
+		slices.SortStableFunc(matched, func(a, b assignment) int {
+			return cmp.Compare(d.prs[b.prID].seq, d.prs[a.prID].seq)
+		})
+
+		for _, a := range matched {
+			stored := d.prs[a.prID]
+			updatedAt := a.stateUpdatedAt
+			prs = append(prs, &domains.PullRequestShort{
+				ID:              stored.id,
+				Name:            stored.name,
+				AuthorID:        stored.authorID,
+				Status:          stored.status,
+				ReviewState:     a.state,
+				ReviewUpdatedAt: &updatedAt,
+			})
+		}
+		return nil
+	})
+	return prs, err
+}
+
+func (p *prRepositoryImpl) GetOpenByReviewers(
+	_ context.Context, reviewerIDs []string) ([]*domains.PullRequest, error) {
+	prs := make([]*domains.PullRequest, 0)
+	err := p.store.read(func(d *data) error {
+		for _, stored := range d.sortedPRs() {
+			if !stored.status.InReview() {
+				continue
+			}
+			for _, i := range d.active(stored.id) {
+				if slices.Contains(reviewerIDs, d.assignments[i].reviewerID) {
+					prs = append(prs, d.pullRequest(stored))
+					break
+				}
+			}
+		}
+		return nil
+	})
+	return prs, err
+}
+
+func (p *prRepositoryImpl) Update(ctx context.Context, pr *domains.PullRequest) error {
+	err := p.store.write(func(d *data) error {
+		stored, err := d.checkVersion(pr)
+		if err != nil {
+			return err
+		}
+		if err := d.checkReviewers(pr); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		previous := stored.status
+		stored.version++
+		stored.name = pr.Name
+		stored.status = pr.Status
+		stored.files = slices.Clone(pr.ChangedFiles)
+		if pr.Status == domains.PRStatusMerged && stored.mergedAt == nil {
+			stored.mergedAt = &now
+		}
+		d.prs[pr.ID] = stored
+
+		reason := pr.ChangeReason
+		if pr.Status == domains.PRStatusClosed {
+			reason = domains.ReplacementClosed
+		} else if reason == "" {
+			reason = domains.ReplacementReassigned
+		}
+		d.syncReviewers(ctx, pr, reason, now)
+
+		if eventType, ok := statusEvents[pr.Status]; ok && previous != pr.Status {
+			d.appendEvent(ctx, domains.PREvent{PullRequestID: pr.ID, Type: eventType}, now)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	pr.Version++
+	return nil
+}
+
+func (p *prRepositoryImpl) MergeWithOverride(
+	ctx context.Context, pr *domains.PullRequest, override *domains.MergeOverride) error {
+	err := p.store.write(func(d *data) error {
+		stored, err := d.checkVersion(pr)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		stored.version++
+		stored.status = domains.PRStatusMerged
+		if stored.mergedAt == nil {
+			stored.mergedAt = &now
+		}
+		d.prs[pr.ID] = stored
+
+		d.overrides = append(d.overrides, domains.MergeOverride{
+			PullRequestID:   override.PullRequestID,
+			ForcedBy:        override.ForcedBy,
+			Reason:          override.Reason,
+			UnmetConditions: slices.Clone(nonNilIDs(override.UnmetConditions)),
+		})
+		d.appendEvent(ctx, domains.PREvent{
+			PullRequestID: pr.ID,
+			Type:          domains.PREventMergeForced,
+			Actor:         override.ForcedBy,
+			Reason:        override.Reason,
+		}, now)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	pr.Version++
+	return nil
+}
+
+func (p *prRepositoryImpl) SetReviewState(
+	ctx context.Context, prID string, reviewerID string, state domains.ReviewState) error {
+	return p.store.write(func(d *data) error {
+		active := d.active(prID)
+		idx := slices.IndexFunc(active, func(i int) bool { return d.assignments[i].reviewerID == reviewerID })
+		if idx == -1 {
+			return repository.ErrConflict
+		}
+		i := active[idx]
+
+		now := time.Now()
+		d.assignments[i].state = state
+		d.assignments[i].stateUpdatedAt = now
+
+		// A verdict changes what a pending merge may rely on, so it invalidates PRs read before it.
+		stored := d.prs[prID]
+		stored.version++
+		d.prs[prID] = stored
+
+		d.appendEvent(ctx, domains.PREvent{
+			PullRequestID: prID,
+			Type:          domains.PREventReviewSubmitted,
+			Actor:         reviewerID,
+			ReviewerID:    reviewerID,
+			State:         state,
+		}, now)
+		return nil
+	})
+}
+
+func (p *prRepositoryImpl) GetEvents(_ context.Context, prID string) ([]domains.PREvent, error) {
+	events := make([]domains.PREvent, 0)
+	err := p.store.read(func(d *data) error {
+		for _, event := range d.events {
+			if event.PullRequestID == prID {
+				events = append(events, event)
+			}
+		}
+		return nil
+	})
+	return events, err
+}
+
+func (p *prRepositoryImpl) GetPendingReviews(_ context.Context) ([]domains.PendingReview, error) {
+	return p.pendingReviews(func(domains.PendingReview) bool { return true })
+}
+
+func (p *prRepositoryImpl) GetOverdueReviews(_ context.Context, teamName string) ([]domains.PendingReview, error) {
+	return p.pendingReviews(func(review domains.PendingReview) bool {
+		return review.OverdueAt != nil && (teamName == "" || review.TeamName == teamName)
+	})
+}
+
+// pendingReviews returns the pending reviews under an SLA accepted by match, oldest first.
+func (p *prRepositoryImpl) pendingReviews(match func(domains.PendingReview) bool) ([]domains.PendingReview, error) {
+	reviews := make([]domains.PendingReview, 0)
+	err := p.store.read(func(d *data) error {
+		for _, a := range d.assignments {
+			if a.replacedAt != nil || a.state != domains.ReviewStatePending {
+				continue
+			}
+			stored := d.prs[a.prID]
+			if !stored.status.InReview() {
+				continue
+			}
+			teamName := d.users[stored.authorID].TeamName
+			settings := d.teams[teamName].settings
+			if settings.ReviewSLAHours <= 0 && settings.EscalationHours <= 0 {
+				continue
+			}
+
+			review := domains.PendingReview{
+				PullRequestID:   stored.id,
+				PullRequestName: stored.name,
+				AuthorID:        stored.authorID,
+				TeamName:        teamName,
+				ReviewerID:      a.reviewerID,
+				AssignedAt:      a.assignedAt,
+				OverdueAt:       a.overdueAt,
+				SLAHours:        settings.ReviewSLAHours,
+				EscalationHours: settings.EscalationHours,
+			}
+			if match(review) {
+				reviews = append(reviews, review)
+			}
+		}
+		slices.SortStableFunc(reviews, func(a, b domains.PendingReview) int {
+			return a.AssignedAt.Compare(b.AssignedAt)
+		})
+		return nil
+	})
+	return reviews, err
+}
+
+func (p *prRepositoryImpl) GetTimedOutReviewers(_ context.Context, prID string) ([]string, error) {
+	reviewers := make([]string, 0)
+	err := p.store.read(func(d *data) error {
+		for _, a := range d.assignments {
+			if a.prID == prID && a.reason == domains.ReplacementSLATimeout && !slices.Contains(reviewers, a.reviewerID) {
+				reviewers = append(reviewers, a.reviewerID)
+			}
+		}
+		return nil
+	})
+	return reviewers, err
+}
+
+func (p *prRepositoryImpl) MarkOverdue(ctx context.Context, reviews []domains.PendingReview, at time.Time) error {
+	return p.store.write(func(d *data) error {
+		now := time.Now()
+		for _, review := range reviews {
+			for _, i := range d.active(review.PullRequestID) {
+				a := &d.assignments[i]
+				if a.reviewerID != review.ReviewerID || a.state != domains.ReviewStatePending || a.overdueAt != nil {
+					continue
+				}
+				overdueAt := at
+				a.overdueAt = &overdueAt
+				d.appendEvent(ctx, domains.PREvent{
+					PullRequestID: review.PullRequestID,
+					Type:          domains.PREventReviewOverdue,
+					ReviewerID:    review.ReviewerID,
+					Reason:        fmt.Sprintf("no review within %d working hours", review.SLAHours),
+				}, now)
+			}
+		}
+		return nil
+	})
+}
+
+func (p *prRepositoryImpl) Count(_ context.Context) (int, error) {
+	var count int
+	err := p.store.read(func(d *data) error {
+		count = len(d.prs)
+		return nil
+	})
+	return count, err
+}
+
+// pullRequest builds the PR as GetByID returns it, without reviews.
+func (d *data) pullRequest(stored pullRequest) *domains.PullRequest {
+	pr := &domains.PullRequest{
+		ID:                stored.id,
+		Name:              stored.name,
+		AuthorID:          stored.authorID,
+		Status:            stored.status,
+		AssignedReviewers: make([]string, 0),
+		OwnerReviewers:    make([]string, 0),
+		ChangedFiles:      append([]string{}, stored.files...),
+		Version:           stored.version,
+	}
+	if stored.mergedAt != nil {
+		mergedAt := *stored.mergedAt
+		pr.MergedAt = &mergedAt
+	}
+	for _, i := range d.active(stored.id) {
+		pr.AssignedReviewers = append(pr.AssignedReviewers, d.assignments[i].reviewerID)
+		if d.assignments[i].isOwner {
+			pr.OwnerReviewers = append(pr.OwnerReviewers, d.assignments[i].reviewerID)
+		}
+	}
+	return pr
+}
+
+// sortedPRs returns the PRs in creation order.
+func (d *data) sortedPRs() []pullRequest {
+	return slices.SortedFunc(maps.Values(d.prs), func(a, b pullRequest) int { return cmp.Compare(a.seq, b.seq) })
+}
+
+// active returns the indexes of the current assignments of prID ordered by position.
+func (d *data) active(prID string) []int {
+	indexes := make([]int, 0)
+	for i, a := range d.assignments {
+		if a.prID == prID && a.replacedAt == nil {
+			indexes = append(indexes, i)
+		}
+	}
+	slices.SortStableFunc(indexes, func(a, b int) int {
+		return d.assignments[a].position - d.assignments[b].position
+	})
+	return indexes
+}
+
+// openReviews counts the active assignments of userID on PRs waiting for review.
+func (d *data) openReviews(userID string) int {
+	count := 0
+	for _, a := range d.assignments {
+		if a.reviewerID == userID && a.replacedAt == nil && d.prs[a.prID].status.InReview() {
+			count++
+		}
+	}
+	return count
+}
+
+// checkVersion fails with repository.ErrConflict when pr was changed since it was read.
+func (d *data) checkVersion(pr *domains.PullRequest) (pullRequest, error) {
+	stored, ok := d.prs[pr.ID]
+	if !ok || stored.version != pr.Version {
+		return pullRequest{}, repository.ErrConflict
+	}
+	return stored, nil
+}
+
+// checkReviewers rejects what the Postgres constraints reject: unknown users and
+// the same reviewer assigned twice.
+func (d *data) checkReviewers(pr *domains.PullRequest) error {
+	for i, reviewerID := range pr.AssignedReviewers {
+		if _, ok := d.users[reviewerID]; !ok {
+			return fmt.Errorf("reviewer %s: %w", reviewerID, errUserNotFound)
+		}
+		if slices.Contains(pr.AssignedReviewers[:i], reviewerID) {
+			return fmt.Errorf("reviewer %s is assigned twice", reviewerID)
+		}
+	}
+	return nil
+}
+
+// syncReviewers brings the active assignments of pr in line with pr.AssignedReviewers.
+// Reviewers that left are closed with reason and, when someone took their place,
+// replaced_by; newcomers start as PENDING.
+func (d *data) syncReviewers(ctx context.Context, pr *domains.PullRequest, reason string, now time.Time) {
+	current := make([]string, 0)
+	for _, i := range d.active(pr.ID) {
+		current = append(current, d.assignments[i].reviewerID)
+	}
+
+	reviewers := nonNilIDs(pr.AssignedReviewers)
+	added := make([]string, 0)
+	for _, reviewer := range reviewers {
+		if !slices.Contains(current, reviewer) {
+			added = append(added, reviewer)
+		}
+	}
+
+	replaced := 0
+	for _, reviewer := range current {
+		if slices.Contains(reviewers, reviewer) {
+			continue
+		}
+		event := domains.PREvent{
+			PullRequestID: pr.ID,
+			Type:          domains.PREventReviewerRemoved,
+			ReviewerID:    reviewer,
+			Reason:        reason,
+		}
+		replacedBy := ""
+		if replaced < len(added) {
+			replacedBy = added[replaced]
+			event.Type = domains.PREventReviewerReplaced
+			event.ReplacedBy = added[replaced]
+		}
+		replaced++
+
+		for _, i := range d.active(pr.ID) {
+			if d.assignments[i].reviewerID == reviewer {
+				replacedAt := now
+				d.assignments[i].replacedAt = &replacedAt
+				d.assignments[i].replacedBy = replacedBy
+				d.assignments[i].reason = reason
+			}
+		}
+		d.appendEvent(ctx, event, now)
+	}
+
+	for i, reviewer := range added {
+		d.assignments = append(d.assignments, assignment{
+			prID:           pr.ID,
+			reviewerID:     reviewer,
+			state:          domains.ReviewStatePending,
+			stateUpdatedAt: now,
+			assignedAt:     now,
+		})
+		if i < replaced {
+			continue
+		}
+		d.appendEvent(ctx, domains.PREvent{
+			PullRequestID: pr.ID, Type: domains.PREventReviewerAssigned, ReviewerID: reviewer,
+		}, now)
+	}
+
+	for _, i := range d.active(pr.ID) {
+		a := &d.assignments[i]
+		a.position = slices.Index(reviewers, a.reviewerID)
+		a.isOwner = slices.Contains(pr.OwnerReviewers, a.reviewerID)
+	}
+}
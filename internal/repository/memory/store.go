@@ -0,0 +1,170 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"ReviewerAssignmentService/internal/domains"
+)
+
+var (
+	errTeamNotFound = errors.New("team not found")
+	errUserNotFound = errors.New("user not found")
+
+	errSubscriptionNotFound = errors.New("webhook subscription not found")
+)
+
+// Store holds the data of the in-memory repositories. Repositories built on the
+// same Store see each other's writes, like repositories sharing one database.
+type Store struct {
+	mu   sync.RWMutex
+	data *data
+
+	// writeMu serializes writers, so that a unit of work holding it can replace
+	// the data with its own snapshot without losing concurrent writes.
+	writeMu sync.Mutex
+}
+
+func NewStore() *Store {
+	return &Store{data: newData()}
+}
+
+func (s *Store) read(fn func(d *data) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return fn(s.data)
+}
+
+// write runs fn with exclusive access to the data. fn must check everything
+// that can fail before it changes anything, so that an error leaves no trace.
+func (s *Store) write(fn func(d *data) error) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return fn(s.data)
+}
+
+type team struct {
+	settings  domains.TeamSettings
+	fallbacks []fallback
+}
+
+type fallback struct {
+	team     string
+	position int
+}
+
+type pullRequest struct {
+	id       string
+	name     string
+	authorID string
+	status   domains.PRStatus
+	files    []string
+	mergedAt *time.Time
+	version  int
+	seq      int64
+}
+
+// assignment mirrors a row of pull_request_reviewers: it stays after the
+// reviewer is replaced, with replacedAt set.
+type assignment struct {
+	prID           string
+	reviewerID     string
+	position       int
+	isOwner        bool
+	state          domains.ReviewState
+	stateUpdatedAt time.Time
+	assignedAt     time.Time
+	replacedAt     *time.Time
+	replacedBy     string
+	reason         string
+	overdueAt      *time.Time
+}
+
+type identityKey struct {
+	provider domains.Provider
+	login    string
+}
+
+type idempotencyKey struct {
+	key      string
+	endpoint string
+}
+
+// data is never modified through shared references: nested slices are
+// replaced rather than edited, so clone can copy it shallowly.
+type data struct {
+	teams          map[string]team
+	users          map[string]domains.User
+	userOrder      []string
+	prs            map[string]pullRequest
+	assignments    []assignment
+	events         []domains.PREvent
+	unavailability []domains.Unavailability
+	ownership      map[string][]domains.OwnershipRule
+	overrides      []domains.MergeOverride
+	subscriptions  []domains.WebhookSubscription
+	deliveries     []domains.WebhookDelivery
+	pending        []domains.PendingDelivery
+	identities     map[identityKey]string
+	idempotency    map[idempotencyKey]domains.IdempotentResponse
+
+	lastPRSeq          int64
+	lastEventID        int64
+	lastUnavailability int64
+	lastSubscriptionID int64
+	lastDeliveryID     int64
+}
+
+func newData() *data {
+	return &data{
+		teams:       make(map[string]team),
+		users:       make(map[string]domains.User),
+		prs:         make(map[string]pullRequest),
+		ownership:   make(map[string][]domains.OwnershipRule),
+		identities:  make(map[identityKey]string),
+		idempotency: make(map[idempotencyKey]domains.IdempotentResponse),
+	}
+}
+
+func (d *data) clone() *data {
+	c := *d
+	c.teams = maps.Clone(d.teams)
+	c.users = maps.Clone(d.users)
+	c.userOrder = slices.Clone(d.userOrder)
+	c.prs = maps.Clone(d.prs)
+	c.assignments = slices.Clone(d.assignments)
+	c.events = slices.Clone(d.events)
+	c.unavailability = slices.Clone(d.unavailability)
+	c.ownership = maps.Clone(d.ownership)
+	c.overrides = slices.Clone(d.overrides)
+	c.subscriptions = slices.Clone(d.subscriptions)
+	c.deliveries = slices.Clone(d.deliveries)
+	c.pending = slices.Clone(d.pending)
+	c.identities = maps.Clone(d.identities)
+	c.idempotency = maps.Clone(d.idempotency)
+	return &c
+}
+
+// appendEvent writes to the PR history; the actor defaults to the one carried by ctx.
+func (d *data) appendEvent(ctx context.Context, event domains.PREvent, now time.Time) {
+	if event.Actor == "" {
+		event.Actor = domains.ActorFromContext(ctx)
+	}
+	d.lastEventID++
+	event.ID = d.lastEventID
+	event.CreatedAt = now
+	d.events = append(d.events, event)
+}
+
+func nonNilIDs(ids []string) []string {
+	if ids == nil {
+		return []string{}
+	}
+	return ids
+}
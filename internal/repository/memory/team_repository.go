@@ -0,0 +1,128 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"ReviewerAssignmentService/internal/domains"
+)
+
+type teamRepositoryImpl struct {
+	store *Store
+}
+
+func NewTeamRepository(store *Store) *teamRepositoryImpl {
+	return &teamRepositoryImpl{store: store}
+}
+
+// Create adds the team unless it exists, then upserts its members and fallback
+// teams. Fallback teams that do not exist are ignored.
+func (t *teamRepositoryImpl) Create(_ context.Context, newTeam *domains.Team) error {
+	return t.store.write(func(d *data) error {
+		stored, exists := d.teams[newTeam.Name]
+		if !exists {
+			settings := newTeam.TeamSettings
+			settings.FallbackTeams = nil
+			if newTeam.MaxOpenReviews != nil {
+				limit := *newTeam.MaxOpenReviews
+				settings.MaxOpenReviews = &limit
+			}
+			stored = team{settings: settings}
+		}
+
+		for _, member := range newTeam.Members {
+			if _, ok := d.users[member.UserID]; !ok {
+				d.userOrder = append(d.userOrder, member.UserID)
+			}
+			d.users[member.UserID] = domains.User{
+				ID:       member.UserID,
+				Name:     member.UserName,
+				TeamName: newTeam.Name,
+				IsActive: member.IsActive,
+			}
+		}
+
+		fallbacks := slices.Clone(stored.fallbacks)
+		for i, name := range newTeam.FallbackTeams {
+			if _, ok := d.teams[name]; !ok && name != newTeam.Name {
+				continue
+			}
+			idx := slices.IndexFunc(fallbacks, func(f fallback) bool { return f.team == name })
+			if idx == -1 {
+				fallbacks = append(fallbacks, fallback{team: name, position: i})
+			} else {
+				fallbacks[idx].position = i
+			}
+		}
+		slices.SortStableFunc(fallbacks, func(a, b fallback) int { return a.position - b.position })
+		stored.fallbacks = fallbacks
+
+		d.teams[newTeam.Name] = stored
+		return nil
+	})
+}
+
+func (t *teamRepositoryImpl) Exists(_ context.Context, teamName string) (bool, error) {
+	var exists bool
+	err := t.store.read(func(d *data) error {
+		_, exists = d.teams[teamName]
+		return nil
+	})
+	return exists, err
+}
+
+func (t *teamRepositoryImpl) GetByName(_ context.Context, name string) (*domains.Team, error) {
+	var result *domains.Team
+	err := t.store.read(func(d *data) error {
+		stored, ok := d.teams[name]
+		if !ok {
+			return nil
+		}
+
+		members := make([]domains.TeamMember, 0)
+		for _, userID := range d.userOrder {
+			user := d.users[userID]
+			if user.TeamName == name {
+				members = append(members, domains.TeamMember{
+					UserID:   user.ID,
+					UserName: user.Name,
+					IsActive: user.IsActive,
+				})
+			}
+		}
+
+		result = &domains.Team{
+			Name:         name,
+			Members:      members,
+			TeamSettings: stored.teamSettings(),
+		}
+		return nil
+	})
+	return result, err
+}
+
+func (t *teamRepositoryImpl) GetSettings(_ context.Context, teamName string) (*domains.TeamSettings, error) {
+	var result *domains.TeamSettings
+	err := t.store.read(func(d *data) error {
+		if stored, ok := d.teams[teamName]; ok {
+			settings := stored.teamSettings()
+			result = &settings
+		}
+		return nil
+	})
+	return result, err
+}
+
+// teamSettings returns a copy of the settings that callers may modify.
+func (t team) teamSettings() domains.TeamSettings {
+	settings := t.settings
+	if t.settings.MaxOpenReviews != nil {
+		limit := *t.settings.MaxOpenReviews
+		settings.MaxOpenReviews = &limit
+	}
+	settings.FallbackTeams = make([]string, 0, len(t.fallbacks))
+	for _, f := range t.fallbacks {
+		settings.FallbackTeams = append(settings.FallbackTeams, f.team)
+	}
+	return settings
+}
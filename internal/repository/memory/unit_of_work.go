@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"context"
+
+	"ReviewerAssignmentService/internal/repository"
+)
+
+type unitOfWorkImpl struct {
+	store *Store
+}
+
+func NewUnitOfWork(store *Store) *unitOfWorkImpl {
+	return &unitOfWorkImpl{store: store}
+}
+
+// WithTx runs fn against a snapshot of the store and publishes the snapshot
+// only if fn succeeds. Other writers wait until the transaction ends, while
+// readers keep seeing the data as it was before it started. fn must only use
+// the repositories it is given.
+func (u *unitOfWorkImpl) WithTx(ctx context.Context, fn func(repos repository.Repositories) error) error {
+	u.store.writeMu.Lock()
+	defer u.store.writeMu.Unlock()
+
+	u.store.mu.RLock()
+	tx := &Store{data: u.store.data.clone()}
+	u.store.mu.RUnlock()
+
+	err := fn(repository.Repositories{
+		PR:        NewPrRepository(tx),
+		User:      NewUserRepository(tx),
+		Team:      NewTeamRepository(tx),
+		Ownership: NewOwnershipRepository(tx),
+	})
+	if err != nil {
+		return err
+	}
+
+	u.store.mu.Lock()
+	u.store.data = tx.data
+	u.store.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,216 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"ReviewerAssignmentService/internal/domains"
+)
+
+type userRepositoryImpl struct {
+	store *Store
+}
+
+func NewUserRepository(store *Store) *userRepositoryImpl {
+	return &userRepositoryImpl{store: store}
+}
+
+func (u *userRepositoryImpl) Create(_ context.Context, user *domains.User) error {
+	return u.store.write(func(d *data) error {
+		if stored, ok := d.users[user.ID]; ok {
+			stored.IsActive = user.IsActive
+			d.users[user.ID] = stored
+			return nil
+		}
+		if _, ok := d.teams[user.TeamName]; !ok {
+			return errTeamNotFound
+		}
+		d.users[user.ID] = *user
+		d.userOrder = append(d.userOrder, user.ID)
+		return nil
+	})
+}
+
+func (u *userRepositoryImpl) Exists(_ context.Context, userID string) (bool, error) {
+	var exists bool
+	err := u.store.read(func(d *data) error {
+		_, exists = d.users[userID]
+		return nil
+	})
+	return exists, err
+}
+
+func (u *userRepositoryImpl) GetByID(_ context.Context, id string) (*domains.User, error) {
+	var result *domains.User
+	err := u.store.read(func(d *data) error {
+		if user, ok := d.users[id]; ok {
+			result = &user
+		}
+		return nil
+	})
+	return result, err
+}
+
+func (u *userRepositoryImpl) GetActiveCandidatesByTeam(
+	_ context.Context, teamName string, excludeUserIDs []string) ([]domains.ReviewCandidate, error) {
+	return u.candidates(func(user domains.User) bool {
+		return user.TeamName == teamName && !slices.Contains(excludeUserIDs, user.ID)
+	})
+}
+
+func (u *userRepositoryImpl) GetActiveCandidatesByIDs(
+	_ context.Context, userIDs []string, excludeUserIDs []string) ([]domains.ReviewCandidate, error) {
+	return u.candidates(func(user domains.User) bool {
+		return slices.Contains(userIDs, user.ID) && !slices.Contains(excludeUserIDs, user.ID)
+	})
+}
+
+// candidates returns the active and available users accepted by match, in random order.
+func (u *userRepositoryImpl) candidates(match func(user domains.User) bool) ([]domains.ReviewCandidate, error) {
+	candidates := make([]domains.ReviewCandidate, 0)
+	err := u.store.read(func(d *data) error {
+		now := time.Now()
+		for _, userID := range d.userOrder {
+			user := d.users[userID]
+			if !user.IsActive || !match(user) || d.unavailable(user.ID, now) {
+				continue
+			}
+			candidates = append(candidates, domains.ReviewCandidate{
+				UserID:      user.ID,
+				OpenReviews: d.openReviews(user.ID),
+			})
+		}
+		return nil
+	})
+	rand.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+	return candidates, err
+}
+
+func (u *userRepositoryImpl) UpdateActivity(_ context.Context, userID string, isActive bool) error {
+	return u.store.write(func(d *data) error {
+		user, ok := d.users[userID]
+		if !ok {
+			return errors.New("user not found")
+		}
+		user.IsActive = isActive
+		d.users[userID] = user
+		return nil
+	})
+}
+
+func (u *userRepositoryImpl) DeactivateTeamMembers(
+	ctx context.Context, teamName string, reassigned []*domains.PullRequest) error {
+	return u.deactivateWithReassignment(ctx, func(user domains.User) bool {
+		return user.TeamName == teamName
+	}, reassigned)
+}
+
+func (u *userRepositoryImpl) Count(_ context.Context) (int, error) {
+	var count int
+	err := u.store.read(func(d *data) error {
+		count = len(d.users)
+		return nil
+	})
+	return count, err
+}
+
+func (u *userRepositoryImpl) AddUnavailability(_ context.Context, period *domains.Unavailability) error {
+	return u.store.write(func(d *data) error {
+		if _, ok := d.users[period.UserID]; !ok {
+			return errUserNotFound
+		}
+		d.lastUnavailability++
+		period.ID = d.lastUnavailability
+		d.unavailability = append(d.unavailability, *period)
+		return nil
+	})
+}
+
+func (u *userRepositoryImpl) GetUnavailability(_ context.Context, userID string) ([]domains.Unavailability, error) {
+	periods := make([]domains.Unavailability, 0)
+	err := u.store.read(func(d *data) error {
+		for _, period := range d.unavailability {
+			if period.UserID == userID {
+				periods = append(periods, period)
+			}
+		}
+		return nil
+	})
+	slices.SortStableFunc(periods, func(a, b domains.Unavailability) int { return a.StartsAt.Compare(b.StartsAt) })
+	return periods, err
+}
+
+func (u *userRepositoryImpl) DeleteUnavailability(_ context.Context, id int64) (bool, error) {
+	var deleted bool
+	err := u.store.write(func(d *data) error {
+		idx := slices.IndexFunc(d.unavailability, func(p domains.Unavailability) bool { return p.ID == id })
+		if idx == -1 {
+			return nil
+		}
+		d.unavailability = slices.Delete(d.unavailability, idx, idx+1)
+		deleted = true
+		return nil
+	})
+	return deleted, err
+}
+
+func (u *userRepositoryImpl) DeactivateUsers(
+	ctx context.Context, userIDs []string, reassigned []*domains.PullRequest) error {
+	return u.deactivateWithReassignment(ctx, func(user domains.User) bool {
+		return slices.Contains(userIDs, user.ID)
+	}, reassigned)
+}
+
+// deactivateWithReassignment deactivates the users accepted by match and updates
+// the reviewers of the affected PRs in one step.
+func (u *userRepositoryImpl) deactivateWithReassignment(
+	ctx context.Context, match func(user domains.User) bool, reassigned []*domains.PullRequest) error {
+	err := u.store.write(func(d *data) error {
+		for _, pr := range reassigned {
+			if _, err := d.checkVersion(pr); err != nil {
+				return err
+			}
+			stored := d.prs[pr.ID]
+			if stored.status.InReview() {
+				if err := d.checkReviewers(pr); err != nil {
+					return err
+				}
+			}
+		}
+
+		for id, user := range d.users {
+			if match(user) {
+				user.IsActive = false
+				d.users[id] = user
+			}
+		}
+
+		now := time.Now()
+		for _, pr := range reassigned {
+			stored := d.prs[pr.ID]
+			stored.version++
+			d.prs[pr.ID] = stored
+			if stored.status.InReview() {
+				d.syncReviewers(ctx, pr, domains.ReplacementDeactivated, now)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, pr := range reassigned {
+		pr.Version++
+	}
+	return nil
+}
+
+// unavailable reports whether userID has an unavailability period covering now.
+func (d *data) unavailable(userID string, now time.Time) bool {
+	return slices.ContainsFunc(d.unavailability, func(p domains.Unavailability) bool {
+		return p.UserID == userID && !p.StartsAt.After(now) && p.EndsAt.After(now)
+	})
+}
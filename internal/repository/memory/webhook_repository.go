@@ -0,0 +1,171 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"ReviewerAssignmentService/internal/domains"
+)
+
+type webhookRepositoryImpl struct {
+	store *Store
+}
+
+func NewWebhookRepository(store *Store) *webhookRepositoryImpl {
+	return &webhookRepositoryImpl{store: store}
+}
+
+// CreateSubscription starts the subscription at the newest event, so only
+// events recorded after it was created are delivered.
+func (w *webhookRepositoryImpl) CreateSubscription(_ context.Context, sub *domains.WebhookSubscription) error {
+	return w.store.write(func(d *data) error {
+		d.lastSubscriptionID++
+		sub.ID = d.lastSubscriptionID
+		sub.LastEventID = d.lastEventID
+		sub.CreatedAt = time.Now()
+
+		stored := *sub
+		stored.Events = slices.Clone(sub.Events)
+		d.subscriptions = append(d.subscriptions, stored)
+		return nil
+	})
+}
+
+func (w *webhookRepositoryImpl) GetSubscriptions(_ context.Context) ([]domains.WebhookSubscription, error) {
+	subs := make([]domains.WebhookSubscription, 0)
+	err := w.store.read(func(d *data) error {
+		for _, sub := range d.subscriptions {
+			sub.Events = append(make([]domains.PREventType, 0, len(sub.Events)), sub.Events...)
+			subs = append(subs, sub)
+		}
+		return nil
+	})
+	return subs, err
+}
+
+func (w *webhookRepositoryImpl) DeleteSubscription(_ context.Context, id int64) (bool, error) {
+	var deleted bool
+	err := w.store.write(func(d *data) error {
+		idx := slices.IndexFunc(d.subscriptions, func(s domains.WebhookSubscription) bool { return s.ID == id })
+		if idx == -1 {
+			return nil
+		}
+		d.subscriptions = slices.Delete(d.subscriptions, idx, idx+1)
+		d.deliveries = slices.DeleteFunc(d.deliveries, func(delivery domains.WebhookDelivery) bool {
+			return delivery.SubscriptionID == id
+		})
+		d.pending = slices.DeleteFunc(d.pending, func(delivery domains.PendingDelivery) bool {
+			return delivery.SubscriptionID == id
+		})
+		deleted = true
+		return nil
+	})
+	return deleted, err
+}
+
+func (w *webhookRepositoryImpl) GetEventsAfter(_ context.Context,
+	afterID int64, types []domains.PREventType, limit int) ([]domains.PREvent, error) {
+	events := make([]domains.PREvent, 0)
+	err := w.store.read(func(d *data) error {
+		for _, event := range d.events {
+			if len(events) == limit {
+				break
+			}
+			if event.ID > afterID && slices.Contains(types, event.Type) {
+				events = append(events, event)
+			}
+		}
+		return nil
+	})
+	return events, err
+}
+
+// QueueDeliveries queues events for the subscription and moves its cursor past
+// them in one step, so an event is never skipped nor queued twice.
+func (w *webhookRepositoryImpl) QueueDeliveries(
+	_ context.Context, subscriptionID int64, events []domains.PREvent, at time.Time) error {
+	return w.store.write(func(d *data) error {
+		idx := slices.IndexFunc(d.subscriptions, func(s domains.WebhookSubscription) bool { return s.ID == subscriptionID })
+		if idx == -1 {
+			return nil
+		}
+		sub := &d.subscriptions[idx]
+		for _, event := range events {
+			sub.LastEventID = max(sub.LastEventID, event.ID)
+			if slices.ContainsFunc(d.pending, func(p domains.PendingDelivery) bool {
+				return p.SubscriptionID == subscriptionID && p.Event.ID == event.ID
+			}) {
+				continue
+			}
+			d.pending = append(d.pending, domains.PendingDelivery{
+				SubscriptionID: subscriptionID,
+				Event:          event,
+				NextAttemptAt:  at,
+			})
+		}
+		return nil
+	})
+}
+
+func (w *webhookRepositoryImpl) GetDueDeliveries(
+	_ context.Context, subscriptionID int64, now time.Time, limit int) ([]domains.PendingDelivery, error) {
+	pending := make([]domains.PendingDelivery, 0)
+	err := w.store.read(func(d *data) error {
+		for _, delivery := range d.pending {
+			if delivery.SubscriptionID == subscriptionID && !delivery.NextAttemptAt.After(now) {
+				pending = append(pending, delivery)
+			}
+		}
+		return nil
+	})
+	slices.SortFunc(pending, func(a, b domains.PendingDelivery) int { return cmp.Compare(a.Event.ID, b.Event.ID) })
+	return pending[:min(limit, len(pending))], err
+}
+
+// RecordDelivery logs an attempt and reschedules the queued event when
+// NextAttemptAt is set or drops it from the queue otherwise.
+func (w *webhookRepositoryImpl) RecordDelivery(_ context.Context, delivery *domains.WebhookDelivery) error {
+	return w.store.write(func(d *data) error {
+		if !slices.ContainsFunc(d.subscriptions, func(s domains.WebhookSubscription) bool {
+			return s.ID == delivery.SubscriptionID
+		}) {
+			return errSubscriptionNotFound
+		}
+		d.lastDeliveryID++
+		delivery.ID = d.lastDeliveryID
+		delivery.CreatedAt = time.Now()
+		d.deliveries = append(d.deliveries, *delivery)
+
+		queued := func(p domains.PendingDelivery) bool {
+			return p.SubscriptionID == delivery.SubscriptionID && p.Event.ID == delivery.EventID
+		}
+		if delivery.NextAttemptAt == nil {
+			d.pending = slices.DeleteFunc(d.pending, queued)
+			return nil
+		}
+		if idx := slices.IndexFunc(d.pending, queued); idx != -1 {
+			d.pending[idx].Attempts = delivery.Attempt
+			d.pending[idx].NextAttemptAt = *delivery.NextAttemptAt
+		}
+		return nil
+	})
+}
+
+func (w *webhookRepositoryImpl) GetDeliveries(
+	_ context.Context, subscriptionID int64, limit int) ([]domains.WebhookDelivery, error) {
+	deliveries := make([]domains.WebhookDelivery, 0)
+	err := w.store.read(func(d *data) error {
+		for _, delivery := range slices.Backward(d.deliveries) {
+			if len(deliveries) == limit {
+				break
+			}
+			if delivery.SubscriptionID == subscriptionID {
+				deliveries = append(deliveries, delivery)
+			}
+		}
+		return nil
+	})
+	return deliveries, err
+}
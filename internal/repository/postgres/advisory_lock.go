@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"context"
+	"log"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// advisoryLockerImpl serializes work across service replicas with Postgres
+// session-level advisory locks held on a dedicated connection.
+type advisoryLockerImpl struct {
+	database *pgxpool.Pool
+}
+
+func NewAdvisoryLocker(database *pgxpool.Pool) *advisoryLockerImpl {
+	return &advisoryLockerImpl{database: database}
+}
+
+// TryWithLock runs fn only if the lock identified by key is free and reports
+// whether it ran.
+func (l *advisoryLockerImpl) TryWithLock(
+	ctx context.Context, key int64, fn func(ctx context.Context) error) (bool, error) {
+	conn, err := l.database.Acquire(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Release()
+
+	var locked bool
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock($1)`, key).Scan(&locked); err != nil {
+		return false, err
+	}
+	if !locked {
+		return false, nil
+	}
+	defer func() {
+		if _, err := conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, key); err != nil {
+			log.Printf("warning: advisory unlock failed: %v", err)
+		}
+	}()
+
+	return true, fn(ctx)
+}
@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"ReviewerAssignmentService/internal/domains"
+)
+
+type idempotencyRepositoryImpl struct {
+	database DBTX
+}
+
+func NewIdempotencyRepository(database DBTX) *idempotencyRepositoryImpl {
+	return &idempotencyRepositoryImpl{database: database}
+}
+
+// Reserve claims the key for a new request unless an unexpired record for it
+// already exists; it reports whether the key was claimed.
+func (i *idempotencyRepositoryImpl) Reserve(ctx context.Context, record *domains.IdempotentResponse) (bool, error) {
+	tx, err := i.database.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Printf("warning: transaction rollback failed: %v", err)
+		}
+	}()
+
+	deleteExpired := `
+		DELETE FROM idempotency_keys
+		WHERE idempotency_key = $1
+		  AND endpoint = $2
+		  AND expires_at <= CURRENT_TIMESTAMP
+	`
+	if _, err := tx.Exec(ctx, deleteExpired, record.Key, record.Endpoint); err != nil {
+		return false, err
+	}
+
+	insert := `
+		INSERT INTO idempotency_keys (idempotency_key, endpoint, request_hash, expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (idempotency_key, endpoint) DO NOTHING
+	`
+	tag, err := tx.Exec(ctx, insert, record.Key, record.Endpoint, record.RequestHash, record.ExpiresAt)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, tx.Commit(ctx)
+}
+
+func (i *idempotencyRepositoryImpl) Get(
+	ctx context.Context, key string, endpoint string) (*domains.IdempotentResponse, error) {
+	query := `
+		SELECT idempotency_key, endpoint, request_hash,
+		       COALESCE(status_code, 0), body, status_code IS NOT NULL, expires_at
+		FROM idempotency_keys
+		WHERE idempotency_key = $1
+		  AND endpoint = $2
+		  AND expires_at > CURRENT_TIMESTAMP
+	`
+
+	var record domains.IdempotentResponse
+	err := i.database.QueryRow(ctx, query, key, endpoint).Scan(&record.Key, &record.Endpoint,
+		&record.RequestHash, &record.StatusCode, &record.Body, &record.Completed, &record.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+func (i *idempotencyRepositoryImpl) Complete(
+	ctx context.Context, key string, endpoint string, statusCode int, body []byte, expiresAt time.Time) error {
+	query := `
+		UPDATE idempotency_keys
+		SET status_code = $3,
+		    body = $4,
+		    expires_at = $5
+		WHERE idempotency_key = $1
+		  AND endpoint = $2
+	`
+	_, err := i.database.Exec(ctx, query, key, endpoint, statusCode, body, expiresAt)
+	return err
+}
+
+func (i *idempotencyRepositoryImpl) Delete(ctx context.Context, key string, endpoint string) error {
+	_, err := i.database.Exec(ctx,
+		`DELETE FROM idempotency_keys WHERE idempotency_key = $1 AND endpoint = $2`, key, endpoint)
+	return err
+}
@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"ReviewerAssignmentService/internal/domains"
+)
+
+type identityRepositoryImpl struct {
+	database DBTX
+}
+
+func NewIdentityRepository(database DBTX) *identityRepositoryImpl {
+	return &identityRepositoryImpl{database: database}
+}
+
+func (i *identityRepositoryImpl) Upsert(ctx context.Context, identity *domains.ExternalIdentity) error {
+	query := `
+		INSERT INTO external_identities (provider, login, user_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (provider, login) DO UPDATE
+		SET user_id = EXCLUDED.user_id
+	`
+	_, err := i.database.Exec(ctx, query, identity.Provider, identity.Login, identity.UserID)
+	return err
+}
+
+func (i *identityRepositoryImpl) GetUserID(
+	ctx context.Context, provider domains.Provider, login string) (string, error) {
+	query := `SELECT user_id FROM external_identities WHERE provider = $1 AND login = $2`
+
+	var userID string
+	err := i.database.QueryRow(ctx, query, provider, login).Scan(&userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	return userID, err
+}
+
+func (i *identityRepositoryImpl) GetByUser(ctx context.Context, userID string) ([]domains.ExternalIdentity, error) {
+	query := `
+		SELECT provider, login, user_id
+		FROM external_identities
+		WHERE user_id = $1
+		ORDER BY provider, login
+	`
+
+	rows, err := i.database.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	identities := make([]domains.ExternalIdentity, 0)
+	for rows.Next() {
+		var identity domains.ExternalIdentity
+		if err := rows.Scan(&identity.Provider, &identity.Login, &identity.UserID); err != nil {
+			return nil, err
+		}
+		identities = append(identities, identity)
+	}
+
+	return identities, rows.Err()
+}
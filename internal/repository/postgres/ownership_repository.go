@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+
+	"github.com/jackc/pgx/v5"
+
+	"ReviewerAssignmentService/internal/domains"
+)
+
+type ownershipRepositoryImpl struct {
+	database DBTX
+}
+
+func NewOwnershipRepository(database DBTX) *ownershipRepositoryImpl {
+	return &ownershipRepositoryImpl{database: database}
+}
+
+func (o *ownershipRepositoryImpl) ReplaceRules(
+	ctx context.Context, teamName string, rules []domains.OwnershipRule) error {
+	tx, err := o.database.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Printf("warning: transaction rollback failed: %v", err)
+		}
+	}()
+
+	var teamID int
+	if err := tx.QueryRow(ctx, "SELECT id FROM teams WHERE team_name = $1", teamName).Scan(&teamID); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, "DELETE FROM ownership_rules WHERE team_id = $1", teamID); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO ownership_rules (team_id, position, pattern, owners)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	for i, rule := range rules {
+		ownersJSON, err := json.Marshal(nonNilIDs(rule.Owners))
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, query, teamID, i, rule.Pattern, string(ownersJSON)); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (o *ownershipRepositoryImpl) GetRules(ctx context.Context, teamName string) ([]domains.OwnershipRule, error) {
+	query := `
+		SELECT r.pattern, r.owners
+		FROM ownership_rules r
+		JOIN teams t ON r.team_id = t.id
+		WHERE t.team_name = $1
+		ORDER BY r.position
+	`
+
+	rows, err := o.database.Query(ctx, query, teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := make([]domains.OwnershipRule, 0)
+	for rows.Next() {
+		var rule domains.OwnershipRule
+		var ownersJSON []byte
+		if err := rows.Scan(&rule.Pattern, &ownersJSON); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(ownersJSON, &rule.Owners); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, rows.Err()
+}
@@ -4,18 +4,57 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
 
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/pgconn"
 
 	"ReviewerAssignmentService/internal/domains"
+	"ReviewerAssignmentService/internal/repository"
 )
 
+const pullRequestColumns = `
+        pr.pull_request_id,
+        pr.pull_request_name,
+        pr.author_id,
+        pr.status,
+        ARRAY(
+            SELECT r.reviewer_id
+            FROM pull_request_reviewers r
+            WHERE r.pull_request_id = pr.pull_request_id
+              AND r.replaced_at IS NULL
+            ORDER BY r.position
+        ),
+        ARRAY(
+            SELECT r.reviewer_id
+            FROM pull_request_reviewers r
+            WHERE r.pull_request_id = pr.pull_request_id
+              AND r.replaced_at IS NULL
+              AND r.is_owner
+            ORDER BY r.position
+        ),
+        pr.changed_files,
+        pr.merged_at,
+        pr.version`
+
+// uniqueViolation is the Postgres SQLSTATE for a unique constraint violation.
+const uniqueViolation = "23505"
+
+var statusEvents = map[domains.PRStatus]domains.PREventType{
+	domains.PRStatusOpen:     domains.PREventReady,
+	domains.PRStatusMerged:   domains.PREventMerged,
+	domains.PRStatusClosed:   domains.PREventClosed,
+	domains.PRStatusReopened: domains.PREventReopened,
+}
+
 type prRepositoryImpl struct {
-	database *pgxpool.Pool
+	database DBTX
 }
 
-func NewPrRepository(database *pgxpool.Pool) *prRepositoryImpl {
+func NewPrRepository(database DBTX) *prRepositoryImpl {
 	return &prRepositoryImpl{database: database}
 }
 
@@ -26,23 +65,44 @@ func (p *prRepositoryImpl) Create(ctx context.Context, pr *domains.PullRequest)
             pull_request_name,
             author_id,
             status,
-            assigned_reviewers
+            changed_files
         ) VALUES ($1, $2, $3, $4, $5)
     `
 
-	reviewersJSON, err := json.Marshal(pr.AssignedReviewers)
+	tx, err := p.database.Begin(ctx)
 	if err != nil {
 		return err
 	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Printf("warning: transaction rollback failed: %v", err)
+		}
+	}()
 
-	_, err = p.database.Exec(ctx, query,
+	_, err = tx.Exec(ctx, query,
 		pr.ID,
 		pr.Name,
 		pr.AuthorID,
 		pr.Status,
-		string(reviewersJSON),
+		nonNilIDs(pr.ChangedFiles),
 	)
-	return err
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return repository.ErrAlreadyExists
+		}
+		return err
+	}
+
+	if err := appendEvent(ctx, tx, domains.PREvent{PullRequestID: pr.ID, Type: domains.PREventCreated}); err != nil {
+		return err
+	}
+
+	if err := syncReviewers(ctx, tx, pr, ""); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
 
 func (p *prRepositoryImpl) Exists(ctx context.Context, prID string) (bool, error) {
@@ -54,23 +114,12 @@ func (p *prRepositoryImpl) Exists(ctx context.Context, prID string) (bool, error
 
 func (p *prRepositoryImpl) GetByID(ctx context.Context, id string) (*domains.PullRequest, error) {
 	query := `
-		SELECT pull_request_id, pull_request_name, author_id, status, assigned_reviewers, merged_at
-		FROM pull_requests
-		WHERE pull_request_id = $1
+		SELECT ` + pullRequestColumns + `
+		FROM pull_requests pr
+		WHERE pr.pull_request_id = $1
 	`
 
-	var pr domains.PullRequest
-	var reviewersJSON []byte
-
-	row := p.database.QueryRow(ctx, query, id)
-	err := row.Scan(
-		&pr.ID,
-		&pr.Name,
-		&pr.AuthorID,
-		&pr.Status,
-		&reviewersJSON,
-		&pr.MergedAt,
-	)
+	pr, err := scanPullRequest(p.database.QueryRow(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
@@ -78,27 +127,54 @@ func (p *prRepositoryImpl) GetByID(ctx context.Context, id string) (*domains.Pul
 		return nil, err
 	}
 
-	if len(reviewersJSON) > 0 {
-		if err := json.Unmarshal(reviewersJSON, &pr.AssignedReviewers); err != nil {
+	if pr.Reviews, err = p.getReviews(ctx, pr.ID); err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+func (p *prRepositoryImpl) getReviews(ctx context.Context, prID string) ([]domains.Review, error) {
+	query := `
+		SELECT reviewer_id, state, state_updated_at
+		FROM pull_request_reviewers
+		WHERE pull_request_id = $1
+		  AND replaced_at IS NULL
+		ORDER BY position
+	`
+
+	rows, err := p.database.Query(ctx, query, prID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := make([]domains.Review, 0)
+	for rows.Next() {
+		var review domains.Review
+		if err := rows.Scan(&review.ReviewerID, &review.State, &review.UpdatedAt); err != nil {
 			return nil, err
 		}
-	} else {
-		pr.AssignedReviewers = []string{}
+		reviews = append(reviews, review)
 	}
 
-	return &pr, nil
+	return reviews, rows.Err()
 }
 
 func (p *prRepositoryImpl) GetByReviewer(ctx context.Context, reviewerID string) ([]*domains.PullRequestShort, error) {
 	query := `
-        SELECT 
-            pull_request_id,
-            pull_request_name,
-            author_id,
-            status
-        FROM pull_requests 
-        WHERE assigned_reviewers @> to_jsonb($1::text)
-        ORDER BY created_at DESC
+        SELECT
+            pr.pull_request_id,
+            pr.pull_request_name,
+            pr.author_id,
+            pr.status,
+            r.state,
+            r.state_updated_at
+        FROM pull_request_reviewers r
+        JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+        WHERE r.reviewer_id = $1
+          AND r.replaced_at IS NULL
+        ORDER BY pr.created_at DESC
     `
 
 	rows, err := p.database.Query(ctx, query, reviewerID)
@@ -115,6 +191,8 @@ func (p *prRepositoryImpl) GetByReviewer(ctx context.Context, reviewerID string)
 			&pr.Name,
 			&pr.AuthorID,
 			&pr.Status,
+			&pr.ReviewState,
+			&pr.ReviewUpdatedAt,
 		)
 		if err != nil {
 			return nil, err
@@ -125,25 +203,344 @@ func (p *prRepositoryImpl) GetByReviewer(ctx context.Context, reviewerID string)
 	return prs, nil
 }
 
+func (p *prRepositoryImpl) GetOpenByReviewers(
+	ctx context.Context, reviewerIDs []string) ([]*domains.PullRequest, error) {
+	query := `
+		SELECT ` + pullRequestColumns + `
+		FROM pull_requests pr
+		WHERE pr.status IN ('OPEN', 'REOPENED')
+		  AND EXISTS (
+		      SELECT 1
+		      FROM pull_request_reviewers r
+		      WHERE r.pull_request_id = pr.pull_request_id
+		        AND r.replaced_at IS NULL
+		        AND r.reviewer_id = ANY($1::text[])
+		  )
+		ORDER BY pr.created_at
+	`
+
+	rows, err := p.database.Query(ctx, query, nonNilIDs(reviewerIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	prs := make([]*domains.PullRequest, 0)
+	for rows.Next() {
+		pr, err := scanPullRequest(rows)
+		if err != nil {
+			return nil, err
+		}
+		prs = append(prs, pr)
+	}
+
+	return prs, rows.Err()
+}
+
 func (p *prRepositoryImpl) Update(ctx context.Context, pr *domains.PullRequest) error {
 	query := `
-        UPDATE pull_requests 
+        UPDATE pull_requests
         SET pull_request_name = $2,
             status = $3::varchar,
-            assigned_reviewers = $4,
+            changed_files = $4,
             merged_at = CASE WHEN $3::varchar = 'MERGED' AND merged_at IS NULL THEN CURRENT_TIMESTAMP ELSE merged_at END
         WHERE pull_request_id = $1
     `
 
-	reviewersJSON, err := json.Marshal(pr.AssignedReviewers)
+	tx, err := p.database.Begin(ctx)
 	if err != nil {
 		return err
 	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Printf("warning: transaction rollback failed: %v", err)
+		}
+	}()
 
-	_, err = p.database.Exec(ctx, query,
-		pr.ID, pr.Name, pr.Status, string(reviewersJSON))
+	previous, err := claimVersion(ctx, tx, pr)
+	if err != nil {
+		return err
+	}
 
-	return err
+	if _, err = tx.Exec(ctx, query, pr.ID, pr.Name, pr.Status, nonNilIDs(pr.ChangedFiles)); err != nil {
+		return err
+	}
+
+	reason := pr.ChangeReason
+	if pr.Status == domains.PRStatusClosed {
+		reason = domains.ReplacementClosed
+	} else if reason == "" {
+		reason = domains.ReplacementReassigned
+	}
+	if err := syncReviewers(ctx, tx, pr, reason); err != nil {
+		return err
+	}
+
+	if eventType, ok := statusEvents[pr.Status]; ok && previous != pr.Status {
+		if err := appendEvent(ctx, tx, domains.PREvent{PullRequestID: pr.ID, Type: eventType}); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	pr.Version++
+	return nil
+}
+
+func (p *prRepositoryImpl) MergeWithOverride(
+	ctx context.Context, pr *domains.PullRequest, override *domains.MergeOverride) error {
+	tx, err := p.database.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Printf("warning: transaction rollback failed: %v", err)
+		}
+	}()
+
+	if _, err := claimVersion(ctx, tx, pr); err != nil {
+		return err
+	}
+
+	queryMerge := `
+		UPDATE pull_requests
+		SET status = 'MERGED',
+		    merged_at = COALESCE(merged_at, CURRENT_TIMESTAMP)
+		WHERE pull_request_id = $1
+	`
+	if _, err := tx.Exec(ctx, queryMerge, pr.ID); err != nil {
+		return err
+	}
+
+	conditionsJSON, err := json.Marshal(nonNilIDs(override.UnmetConditions))
+	if err != nil {
+		return err
+	}
+
+	queryAudit := `
+		INSERT INTO merge_overrides (pull_request_id, forced_by, reason, unmet_conditions)
+		VALUES ($1, $2, $3, $4)
+	`
+	if _, err := tx.Exec(ctx, queryAudit,
+		override.PullRequestID, override.ForcedBy, override.Reason, string(conditionsJSON)); err != nil {
+		return err
+	}
+
+	err = appendEvent(ctx, tx, domains.PREvent{
+		PullRequestID: pr.ID,
+		Type:          domains.PREventMergeForced,
+		Actor:         override.ForcedBy,
+		Reason:        override.Reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	pr.Version++
+	return nil
+}
+
+func (p *prRepositoryImpl) SetReviewState(
+	ctx context.Context, prID string, reviewerID string, state domains.ReviewState) error {
+	query := `
+		UPDATE pull_request_reviewers
+		SET state = $3,
+		    state_updated_at = CURRENT_TIMESTAMP
+		WHERE pull_request_id = $1
+		  AND reviewer_id = $2
+		  AND replaced_at IS NULL
+	`
+
+	tx, err := p.database.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Printf("warning: transaction rollback failed: %v", err)
+		}
+	}()
+
+	tag, err := tx.Exec(ctx, query, prID, reviewerID, state)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return repository.ErrConflict
+	}
+
+	// A verdict changes what a pending merge may rely on, so it invalidates PRs read before it.
+	queryVersion := `UPDATE pull_requests SET version = version + 1 WHERE pull_request_id = $1`
+	if _, err := tx.Exec(ctx, queryVersion, prID); err != nil {
+		return err
+	}
+
+	err = appendEvent(ctx, tx, domains.PREvent{
+		PullRequestID: prID,
+		Type:          domains.PREventReviewSubmitted,
+		Actor:         reviewerID,
+		ReviewerID:    reviewerID,
+		State:         state,
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (p *prRepositoryImpl) GetEvents(ctx context.Context, prID string) ([]domains.PREvent, error) {
+	query := `
+		SELECT id, pull_request_id, event_type,
+		       COALESCE(actor, ''), COALESCE(reviewer_id, ''), COALESCE(replaced_by, ''),
+		       COALESCE(state, ''), COALESCE(reason, ''), created_at
+		FROM pr_events
+		WHERE pull_request_id = $1
+		ORDER BY id
+	`
+
+	rows, err := p.database.Query(ctx, query, prID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]domains.PREvent, 0)
+	for rows.Next() {
+		var event domains.PREvent
+		err := rows.Scan(&event.ID, &event.PullRequestID, &event.Type,
+			&event.Actor, &event.ReviewerID, &event.ReplacedBy,
+			&event.State, &event.Reason, &event.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+const pendingReviewsQuery = `
+	SELECT r.pull_request_id, pr.pull_request_name, pr.author_id, t.team_name,
+	       r.reviewer_id, r.assigned_at, r.overdue_at, t.review_sla_hours, t.escalation_hours
+	FROM pull_request_reviewers r
+	JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+	JOIN users a ON a.user_id = pr.author_id
+	JOIN teams t ON t.id = a.team_id
+	WHERE r.replaced_at IS NULL
+	  AND r.state = 'PENDING'
+	  AND pr.status IN ('OPEN', 'REOPENED')
+	  AND (t.review_sla_hours > 0 OR t.escalation_hours > 0)
+`
+
+func (p *prRepositoryImpl) GetPendingReviews(ctx context.Context) ([]domains.PendingReview, error) {
+	return p.queryPendingReviews(ctx, pendingReviewsQuery+` ORDER BY r.assigned_at`)
+}
+
+func (p *prRepositoryImpl) GetOverdueReviews(ctx context.Context, teamName string) ([]domains.PendingReview, error) {
+	query := pendingReviewsQuery + `
+	  AND r.overdue_at IS NOT NULL
+	  AND ($1 = '' OR t.team_name = $1)
+	ORDER BY r.assigned_at`
+	return p.queryPendingReviews(ctx, query, teamName)
+}
+
+func (p *prRepositoryImpl) queryPendingReviews(
+	ctx context.Context, query string, args ...any) ([]domains.PendingReview, error) {
+	rows, err := p.database.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := make([]domains.PendingReview, 0)
+	for rows.Next() {
+		var review domains.PendingReview
+		err := rows.Scan(&review.PullRequestID, &review.PullRequestName, &review.AuthorID, &review.TeamName,
+			&review.ReviewerID, &review.AssignedAt, &review.OverdueAt, &review.SLAHours, &review.EscalationHours)
+		if err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, review)
+	}
+
+	return reviews, rows.Err()
+}
+
+func (p *prRepositoryImpl) GetTimedOutReviewers(ctx context.Context, prID string) ([]string, error) {
+	query := `
+		SELECT DISTINCT reviewer_id
+		FROM pull_request_reviewers
+		WHERE pull_request_id = $1
+		  AND reason = $2
+	`
+
+	rows, err := p.database.Query(ctx, query, prID, domains.ReplacementSLATimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviewers := make([]string, 0)
+	for rows.Next() {
+		var reviewerID string
+		if err := rows.Scan(&reviewerID); err != nil {
+			return nil, err
+		}
+		reviewers = append(reviewers, reviewerID)
+	}
+
+	return reviewers, rows.Err()
+}
+
+func (p *prRepositoryImpl) MarkOverdue(ctx context.Context, reviews []domains.PendingReview, at time.Time) error {
+	query := `
+		UPDATE pull_request_reviewers
+		SET overdue_at = $3
+		WHERE pull_request_id = $1
+		  AND reviewer_id = $2
+		  AND replaced_at IS NULL
+		  AND state = 'PENDING'
+		  AND overdue_at IS NULL
+	`
+
+	tx, err := p.database.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Printf("warning: transaction rollback failed: %v", err)
+		}
+	}()
+
+	for _, review := range reviews {
+		tag, err := tx.Exec(ctx, query, review.PullRequestID, review.ReviewerID, at)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			continue
+		}
+
+		err = appendEvent(ctx, tx, domains.PREvent{
+			PullRequestID: review.PullRequestID,
+			Type:          domains.PREventReviewOverdue,
+			ReviewerID:    review.ReviewerID,
+			Reason:        fmt.Sprintf("no review within %d working hours", review.SLAHours),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
 }
 
 func (p *prRepositoryImpl) Count(ctx context.Context) (int, error) {
@@ -151,3 +548,214 @@ func (p *prRepositoryImpl) Count(ctx context.Context) (int, error) {
 	err := p.database.QueryRow(ctx, "SELECT COUNT(*) FROM pull_requests").Scan(&count)
 	return count, err
 }
+
+func scanPullRequest(row pgx.Row) (*domains.PullRequest, error) {
+	var pr domains.PullRequest
+
+	err := row.Scan(
+		&pr.ID,
+		&pr.Name,
+		&pr.AuthorID,
+		&pr.Status,
+		&pr.AssignedReviewers,
+		&pr.OwnerReviewers,
+		&pr.ChangedFiles,
+		&pr.MergedAt,
+		&pr.Version,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	pr.AssignedReviewers = nonNilIDs(pr.AssignedReviewers)
+	return &pr, nil
+}
+
+// claimVersion locks the row of pr and bumps its version, failing with
+// repository.ErrConflict when pr was changed since it was read. It returns the
+// status stored before this write.
+func claimVersion(ctx context.Context, tx pgx.Tx, pr *domains.PullRequest) (domains.PRStatus, error) {
+	query := `
+		UPDATE pull_requests
+		SET version = version + 1
+		WHERE pull_request_id = $1
+		  AND version = $2
+		RETURNING status
+	`
+	var status domains.PRStatus
+	err := tx.QueryRow(ctx, query, pr.ID, pr.Version).Scan(&status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", repository.ErrConflict
+	}
+	return status, err
+}
+
+// claimVersions is claimVersion for many PRs in one statement; it fails with
+// ErrConflict if any of them has moved on.
+func claimVersions(ctx context.Context, tx pgx.Tx, prs []*domains.PullRequest) (map[string]domains.PRStatus, error) {
+	ids := make([]string, 0, len(prs))
+	versions := make([]int, 0, len(prs))
+	for _, pr := range prs {
+		ids = append(ids, pr.ID)
+		versions = append(versions, pr.Version)
+	}
+
+	rows, err := tx.Query(ctx, `
+		UPDATE pull_requests pr
+		SET version = pr.version + 1
+		FROM unnest($1::text[], $2::int[]) AS v(pull_request_id, version)
+		WHERE pr.pull_request_id = v.pull_request_id
+		  AND pr.version = v.version
+		RETURNING pr.pull_request_id, pr.status
+	`, ids, versions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	statuses := make(map[string]domains.PRStatus, len(prs))
+	for rows.Next() {
+		var id string
+		var status domains.PRStatus
+		if err := rows.Scan(&id, &status); err != nil {
+			return nil, err
+		}
+		statuses[id] = status
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(statuses) != len(prs) {
+		return nil, repository.ErrConflict
+	}
+	return statuses, nil
+}
+
+// activeReviewers locks and returns the current reviewers of each PR, in position order.
+func activeReviewers(ctx context.Context, tx pgx.Tx, prIDs []string) (map[string][]string, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT pull_request_id, reviewer_id
+		FROM pull_request_reviewers
+		WHERE pull_request_id = ANY($1)
+		  AND replaced_at IS NULL
+		ORDER BY pull_request_id, position
+		FOR UPDATE
+	`, prIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	current := make(map[string][]string, len(prIDs))
+	for rows.Next() {
+		var prID, reviewer string
+		if err := rows.Scan(&prID, &reviewer); err != nil {
+			return nil, err
+		}
+		current[prID] = append(current[prID], reviewer)
+	}
+	return current, rows.Err()
+}
+
+// syncReviewers brings the active assignments of pr in line with pr.AssignedReviewers.
+func syncReviewers(ctx context.Context, tx pgx.Tx, pr *domains.PullRequest, reason string) error {
+	current, err := activeReviewers(ctx, tx, []string{pr.ID})
+	if err != nil {
+		return err
+	}
+	batch := &pgx.Batch{}
+	queueReviewerSync(ctx, batch, pr, current[pr.ID], reason)
+	return tx.SendBatch(ctx, batch).Close()
+}
+
+// queueReviewerSync queues the statements that turn current into pr.AssignedReviewers.
+// Reviewers that left are closed with reason and, when someone took their place,
+// replaced_by; newcomers start as PENDING.
+func queueReviewerSync(ctx context.Context, batch *pgx.Batch, pr *domains.PullRequest, current []string, reason string) {
+	reviewers := nonNilIDs(pr.AssignedReviewers)
+	added := make([]string, 0)
+	for _, reviewer := range reviewers {
+		if !slices.Contains(current, reviewer) {
+			added = append(added, reviewer)
+		}
+	}
+
+	queryReplace := `
+		UPDATE pull_request_reviewers
+		SET replaced_at = CURRENT_TIMESTAMP,
+		    replaced_by = $3,
+		    reason = NULLIF($4, '')
+		WHERE pull_request_id = $1
+		  AND reviewer_id = $2
+		  AND replaced_at IS NULL
+	`
+	replaced := 0
+	for _, reviewer := range current {
+		if slices.Contains(reviewers, reviewer) {
+			continue
+		}
+		event := domains.PREvent{
+			PullRequestID: pr.ID,
+			Type:          domains.PREventReviewerRemoved,
+			ReviewerID:    reviewer,
+			Reason:        reason,
+		}
+		var replacedBy *string
+		if replaced < len(added) {
+			replacedBy = &added[replaced]
+			event.Type = domains.PREventReviewerReplaced
+			event.ReplacedBy = added[replaced]
+		}
+		replaced++
+		batch.Queue(queryReplace, pr.ID, reviewer, replacedBy, reason)
+		queueEvent(ctx, batch, event)
+	}
+
+	queryInsert := `
+		INSERT INTO pull_request_reviewers (pull_request_id, reviewer_id)
+		VALUES ($1, $2)
+	`
+	for i, reviewer := range added {
+		batch.Queue(queryInsert, pr.ID, reviewer)
+		if i < replaced {
+			continue
+		}
+		queueEvent(ctx, batch, domains.PREvent{PullRequestID: pr.ID, Type: domains.PREventReviewerAssigned, ReviewerID: reviewer})
+	}
+
+	queryPositions := `
+		UPDATE pull_request_reviewers r
+		SET position = v.position - 1,
+		    is_owner = r.reviewer_id = ANY($3::text[])
+		FROM unnest($2::text[]) WITH ORDINALITY AS v(reviewer_id, position)
+		WHERE r.pull_request_id = $1
+		  AND r.replaced_at IS NULL
+		  AND r.reviewer_id = v.reviewer_id
+	`
+	batch.Queue(queryPositions, pr.ID, reviewers, nonNilIDs(pr.OwnerReviewers))
+}
+
+const insertEventQuery = `
+	INSERT INTO pr_events (pull_request_id, event_type, actor, reviewer_id, replaced_by, state, reason)
+	VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''))
+`
+
+// appendEvent writes to the PR history; the actor defaults to the one carried by ctx.
+func appendEvent(ctx context.Context, tx pgx.Tx, event domains.PREvent) error {
+	if event.Actor == "" {
+		event.Actor = domains.ActorFromContext(ctx)
+	}
+
+	_, err := tx.Exec(ctx, insertEventQuery, event.PullRequestID, event.Type,
+		event.Actor, event.ReviewerID, event.ReplacedBy, event.State, event.Reason)
+	return err
+}
+
+// queueEvent is appendEvent for statements sent together in a batch.
+func queueEvent(ctx context.Context, batch *pgx.Batch, event domains.PREvent) {
+	if event.Actor == "" {
+		event.Actor = domains.ActorFromContext(ctx)
+	}
+	batch.Queue(insertEventQuery, event.PullRequestID, event.Type,
+		event.Actor, event.ReviewerID, event.ReplacedBy, event.State, event.Reason)
+}
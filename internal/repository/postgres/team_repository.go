@@ -6,16 +6,31 @@ import (
 	"log"
 
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 
 	"ReviewerAssignmentService/internal/domains"
 )
 
+const teamSettingsColumns = `
+        COALESCE(reviewer_strategy, ''),
+        max_open_reviews,
+        required_reviewers,
+        ARRAY(
+            SELECT ft.team_name
+            FROM team_fallbacks f
+            JOIN teams ft ON ft.id = f.fallback_team_id
+            WHERE f.team_id = teams.id
+            ORDER BY f.position
+        ),
+        min_approvals,
+        require_owner_approval,
+        review_sla_hours,
+        escalation_hours`
+
 type teamRepositoryImpl struct {
-	database *pgxpool.Pool
+	database DBTX
 }
 
-func NewTeamRepository(database *pgxpool.Pool) *teamRepositoryImpl {
+func NewTeamRepository(database DBTX) *teamRepositoryImpl {
 	return &teamRepositoryImpl{database: database}
 }
 
@@ -32,8 +47,11 @@ func (t *teamRepositoryImpl) Create(ctx context.Context, team *domains.Team) err
 
 	queryTeam := `
         WITH ins AS (
-            INSERT INTO teams (team_name) 
-            VALUES ($1)
+            INSERT INTO teams (
+                team_name, reviewer_strategy, max_open_reviews, required_reviewers,
+                min_approvals, require_owner_approval, review_sla_hours, escalation_hours
+            )
+            VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6, $7, $8)
             ON CONFLICT (team_name) DO NOTHING
             RETURNING id
         )
@@ -43,7 +61,10 @@ func (t *teamRepositoryImpl) Create(ctx context.Context, team *domains.Team) err
         LIMIT 1;
     `
 	var teamID int
-	if err := tx.QueryRow(ctx, queryTeam, team.Name).Scan(&teamID); err != nil {
+	if err := tx.QueryRow(ctx, queryTeam,
+		team.Name, team.ReviewerStrategy, team.MaxOpenReviews, team.RequiredReviewers,
+		team.MinApprovals, team.RequireOwnerApproval, team.ReviewSLAHours, team.EscalationHours,
+	).Scan(&teamID); err != nil {
 		return err
 	}
 
@@ -63,6 +84,19 @@ func (t *teamRepositoryImpl) Create(ctx context.Context, team *domains.Team) err
 		}
 	}
 
+	queryFallback := `
+		INSERT INTO team_fallbacks (team_id, fallback_team_id, position)
+		SELECT $1, id, $3 FROM teams WHERE team_name = $2
+		ON CONFLICT (team_id, fallback_team_id) DO UPDATE
+		SET position = EXCLUDED.position
+	`
+
+	for i, fallbackTeam := range team.FallbackTeams {
+		if _, err = tx.Exec(ctx, queryFallback, teamID, fallbackTeam, i); err != nil {
+			return err
+		}
+	}
+
 	return tx.Commit(ctx)
 }
 
@@ -77,7 +111,9 @@ func (t *teamRepositoryImpl) Exists(ctx context.Context, teamName string) (bool,
 
 func (t *teamRepositoryImpl) GetByName(ctx context.Context, name string) (*domains.Team, error) {
 	var teamID int
-	err := t.database.QueryRow(ctx, "SELECT id FROM teams WHERE team_name = $1", name).Scan(&teamID)
+	var settings domains.TeamSettings
+	query := `SELECT id, ` + teamSettingsColumns + ` FROM teams WHERE team_name = $1`
+	err := t.database.QueryRow(ctx, query, name).Scan(append([]any{&teamID}, settingsFields(&settings)...)...)
 	if err != nil {
 		return nil, nil
 	}
@@ -105,7 +141,36 @@ func (t *teamRepositoryImpl) GetByName(ctx context.Context, name string) (*domai
 	}
 
 	return &domains.Team{
-		Name:    name,
-		Members: members,
+		Name:         name,
+		Members:      members,
+		TeamSettings: settings,
 	}, nil
 }
+
+func (t *teamRepositoryImpl) GetSettings(ctx context.Context, teamName string) (*domains.TeamSettings, error) {
+	query := `SELECT ` + teamSettingsColumns + ` FROM teams WHERE team_name = $1`
+
+	var settings domains.TeamSettings
+	err := t.database.QueryRow(ctx, query, teamName).Scan(settingsFields(&settings)...)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+func settingsFields(settings *domains.TeamSettings) []any {
+	return []any{
+		&settings.ReviewerStrategy,
+		&settings.MaxOpenReviews,
+		&settings.RequiredReviewers,
+		&settings.FallbackTeams,
+		&settings.MinApprovals,
+		&settings.RequireOwnerApproval,
+		&settings.ReviewSLAHours,
+		&settings.EscalationHours,
+	}
+}
@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"ReviewerAssignmentService/internal/repository"
+)
+
+type unitOfWorkImpl struct {
+	database *pgxpool.Pool
+}
+
+func NewUnitOfWork(database *pgxpool.Pool) *unitOfWorkImpl {
+	return &unitOfWorkImpl{database: database}
+}
+
+func (u *unitOfWorkImpl) WithTx(ctx context.Context, fn func(repos repository.Repositories) error) error {
+	tx, err := u.database.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Printf("warning: transaction rollback failed: %v", err)
+		}
+	}()
+
+	repos := repository.Repositories{
+		PR:        NewPrRepository(tx),
+		User:      NewUserRepository(tx),
+		Team:      NewTeamRepository(tx),
+		Ownership: NewOwnershipRepository(tx),
+	}
+	if err := fn(repos); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
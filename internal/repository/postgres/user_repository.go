@@ -3,18 +3,36 @@ package postgres
 import (
 	"context"
 	"errors"
+	"log"
 
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 
 	"ReviewerAssignmentService/internal/domains"
 )
 
+const candidateColumns = `
+        u.user_id,
+        (SELECT COUNT(*)
+         FROM pull_request_reviewers r
+         JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+         WHERE pr.status IN ('OPEN', 'REOPENED')
+           AND r.reviewer_id = u.user_id
+           AND r.replaced_at IS NULL) AS open_reviews`
+
+const availableCondition = `
+        NOT EXISTS (
+            SELECT 1
+            FROM user_unavailability ua
+            WHERE ua.user_id = u.user_id
+              AND ua.starts_at <= CURRENT_TIMESTAMP
+              AND ua.ends_at > CURRENT_TIMESTAMP
+        )`
+
 type userRepositoryImpl struct {
-	database *pgxpool.Pool
+	database DBTX
 }
 
-func NewUserRepository(database *pgxpool.Pool) *userRepositoryImpl {
+func NewUserRepository(database DBTX) *userRepositoryImpl {
 	return &userRepositoryImpl{database: database}
 }
 
@@ -64,36 +82,57 @@ func (u *userRepositoryImpl) GetByID(ctx context.Context, id string) (*domains.U
 	return &user, nil
 }
 
-func (u *userRepositoryImpl) GetRandomActiveUsersByTeam(
-	ctx context.Context, teamName string, excludeUserID string, limit int) ([]string, error) {
+func (u *userRepositoryImpl) GetActiveCandidatesByTeam(
+	ctx context.Context, teamName string, excludeUserIDs []string) ([]domains.ReviewCandidate, error) {
 
 	query := `
-        SELECT u.user_id 
+        SELECT ` + candidateColumns + `
         FROM users u
         JOIN teams t ON u.team_id = t.id
-        WHERE t.team_name = $1 
-          AND u.is_active = TRUE 
-          AND u.user_id != $2
+        WHERE t.team_name = $1
+          AND u.is_active = TRUE
+          AND u.user_id <> ALL($2::text[])
+          AND ` + availableCondition + `
         ORDER BY RANDOM()
-        LIMIT $3
     `
 
-	rows, err := u.database.Query(ctx, query, teamName, excludeUserID, limit)
+	return u.queryCandidates(ctx, query, teamName, nonNilIDs(excludeUserIDs))
+}
+
+func (u *userRepositoryImpl) GetActiveCandidatesByIDs(
+	ctx context.Context, userIDs []string, excludeUserIDs []string) ([]domains.ReviewCandidate, error) {
+
+	query := `
+        SELECT ` + candidateColumns + `
+        FROM users u
+        WHERE u.user_id = ANY($1::text[])
+          AND u.is_active = TRUE
+          AND u.user_id <> ALL($2::text[])
+          AND ` + availableCondition + `
+        ORDER BY RANDOM()
+    `
+
+	return u.queryCandidates(ctx, query, nonNilIDs(userIDs), nonNilIDs(excludeUserIDs))
+}
+
+func (u *userRepositoryImpl) queryCandidates(
+	ctx context.Context, query string, args ...any) ([]domains.ReviewCandidate, error) {
+	rows, err := u.database.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	userIDs := make([]string, 0)
+	candidates := make([]domains.ReviewCandidate, 0)
 	for rows.Next() {
-		var id string
-		if err := rows.Scan(&id); err != nil {
+		var candidate domains.ReviewCandidate
+		if err := rows.Scan(&candidate.UserID, &candidate.OpenReviews); err != nil {
 			return nil, err
 		}
-		userIDs = append(userIDs, id)
+		candidates = append(candidates, candidate)
 	}
 
-	return userIDs, nil
+	return candidates, rows.Err()
 }
 
 func (u *userRepositoryImpl) UpdateActivity(ctx context.Context, userID string, isActive bool) error {
@@ -105,14 +144,14 @@ func (u *userRepositoryImpl) UpdateActivity(ctx context.Context, userID string,
 	return err
 }
 
-func (u *userRepositoryImpl) DeactivateTeamMembers(ctx context.Context, teamName string) error {
+func (u *userRepositoryImpl) DeactivateTeamMembers(
+	ctx context.Context, teamName string, reassigned []*domains.PullRequest) error {
 	query := `
 		UPDATE users 
 		SET is_active = false 
 		WHERE team_id = (SELECT id FROM teams WHERE team_name = $1)
 	`
-	_, err := u.database.Exec(ctx, query, teamName)
-	return err
+	return u.deactivateWithReassignment(ctx, query, teamName, reassigned)
 }
 
 func (u *userRepositoryImpl) Count(ctx context.Context) (int, error) {
@@ -120,3 +159,127 @@ func (u *userRepositoryImpl) Count(ctx context.Context) (int, error) {
 	err := u.database.QueryRow(ctx, "SELECT COUNT(*) FROM users").Scan(&count)
 	return count, err
 }
+
+func (u *userRepositoryImpl) AddUnavailability(ctx context.Context, period *domains.Unavailability) error {
+	query := `
+		INSERT INTO user_unavailability (user_id, starts_at, ends_at, reason)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	return u.database.QueryRow(ctx, query,
+		period.UserID, period.StartsAt, period.EndsAt, period.Reason,
+	).Scan(&period.ID)
+}
+
+func (u *userRepositoryImpl) GetUnavailability(ctx context.Context, userID string) ([]domains.Unavailability, error) {
+	query := `
+		SELECT id, user_id, starts_at, ends_at, reason
+		FROM user_unavailability
+		WHERE user_id = $1
+		ORDER BY starts_at
+	`
+
+	rows, err := u.database.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	periods := make([]domains.Unavailability, 0)
+	for rows.Next() {
+		var period domains.Unavailability
+		err := rows.Scan(&period.ID, &period.UserID, &period.StartsAt, &period.EndsAt, &period.Reason)
+		if err != nil {
+			return nil, err
+		}
+		periods = append(periods, period)
+	}
+
+	return periods, rows.Err()
+}
+
+func (u *userRepositoryImpl) DeleteUnavailability(ctx context.Context, id int64) (bool, error) {
+	tag, err := u.database.Exec(ctx, `DELETE FROM user_unavailability WHERE id = $1`, id)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (u *userRepositoryImpl) DeactivateUsers(
+	ctx context.Context, userIDs []string, reassigned []*domains.PullRequest) error {
+	query := `UPDATE users SET is_active = FALSE WHERE user_id = ANY($1::text[])`
+	return u.deactivateWithReassignment(ctx, query, userIDs, reassigned)
+}
+
+// deactivateWithReassignment runs the deactivation query and the reviewer updates
+// of the affected PRs in one transaction.
+func (u *userRepositoryImpl) deactivateWithReassignment(
+	ctx context.Context, query string, arg any, reassigned []*domains.PullRequest) error {
+	tx, err := u.database.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Printf("warning: transaction rollback failed: %v", err)
+		}
+	}()
+
+	if _, err := tx.Exec(ctx, query, arg); err != nil {
+		return err
+	}
+
+	if len(reassigned) > 0 {
+		if err := batchSyncReviewers(ctx, tx, reassigned, domains.ReplacementDeactivated); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	for _, pr := range reassigned {
+		pr.Version++
+	}
+	return nil
+}
+
+// batchSyncReviewers claims the versions of prs and syncs the reviewers of those still
+// in review with a fixed number of round trips, however many PRs there are.
+func batchSyncReviewers(ctx context.Context, tx pgx.Tx, prs []*domains.PullRequest, reason string) error {
+	statuses, err := claimVersions(ctx, tx, prs)
+	if err != nil {
+		return err
+	}
+
+	inReview := make([]*domains.PullRequest, 0, len(prs))
+	ids := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		if statuses[pr.ID].InReview() {
+			inReview = append(inReview, pr)
+			ids = append(ids, pr.ID)
+		}
+	}
+	if len(inReview) == 0 {
+		return nil
+	}
+
+	current, err := activeReviewers(ctx, tx, ids)
+	if err != nil {
+		return err
+	}
+	batch := &pgx.Batch{}
+	for _, pr := range inReview {
+		queueReviewerSync(ctx, batch, pr, current[pr.ID], reason)
+	}
+	return tx.SendBatch(ctx, batch).Close()
+}
+
+func nonNilIDs(ids []string) []string {
+	if ids == nil {
+		return []string{}
+	}
+	return ids
+}
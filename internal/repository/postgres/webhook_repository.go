@@ -0,0 +1,275 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"ReviewerAssignmentService/internal/domains"
+)
+
+type webhookRepositoryImpl struct {
+	database DBTX
+}
+
+func NewWebhookRepository(database DBTX) *webhookRepositoryImpl {
+	return &webhookRepositoryImpl{database: database}
+}
+
+// CreateSubscription starts the subscription at the newest event, so only
+// events recorded after it was created are delivered.
+func (w *webhookRepositoryImpl) CreateSubscription(ctx context.Context, sub *domains.WebhookSubscription) error {
+	query := `
+		INSERT INTO webhook_subscriptions (url, secret, events, last_event_id)
+		VALUES ($1, $2, $3, (SELECT COALESCE(MAX(id), 0) FROM pr_events))
+		RETURNING id, last_event_id, created_at
+	`
+
+	return w.database.QueryRow(ctx, query, sub.URL, sub.Secret, eventTypes(sub.Events)).
+		Scan(&sub.ID, &sub.LastEventID, &sub.CreatedAt)
+}
+
+func (w *webhookRepositoryImpl) GetSubscriptions(ctx context.Context) ([]domains.WebhookSubscription, error) {
+	query := `
+		SELECT id, url, secret, events, last_event_id, created_at
+		FROM webhook_subscriptions
+		ORDER BY id
+	`
+
+	rows, err := w.database.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subs := make([]domains.WebhookSubscription, 0)
+	for rows.Next() {
+		var sub domains.WebhookSubscription
+		var events []string
+		err := rows.Scan(&sub.ID, &sub.URL, &sub.Secret, &events, &sub.LastEventID, &sub.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		sub.Events = make([]domains.PREventType, 0, len(events))
+		for _, event := range events {
+			sub.Events = append(sub.Events, domains.PREventType(event))
+		}
+		subs = append(subs, sub)
+	}
+
+	return subs, rows.Err()
+}
+
+func (w *webhookRepositoryImpl) DeleteSubscription(ctx context.Context, id int64) (bool, error) {
+	tag, err := w.database.Exec(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (w *webhookRepositoryImpl) GetEventsAfter(ctx context.Context,
+	afterID int64, types []domains.PREventType, limit int) ([]domains.PREvent, error) {
+	query := `
+		SELECT id, pull_request_id, event_type,
+		       COALESCE(actor, ''), COALESCE(reviewer_id, ''), COALESCE(replaced_by, ''),
+		       COALESCE(state, ''), COALESCE(reason, ''), created_at
+		FROM pr_events
+		WHERE id > $1
+		  AND event_type = ANY($2::text[])
+		ORDER BY id
+		LIMIT $3
+	`
+
+	rows, err := w.database.Query(ctx, query, afterID, eventTypes(types), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]domains.PREvent, 0)
+	for rows.Next() {
+		var event domains.PREvent
+		err := rows.Scan(&event.ID, &event.PullRequestID, &event.Type,
+			&event.Actor, &event.ReviewerID, &event.ReplacedBy,
+			&event.State, &event.Reason, &event.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// QueueDeliveries queues events for the subscription and moves its cursor past
+// them in one transaction, so an event is never skipped nor queued twice.
+func (w *webhookRepositoryImpl) QueueDeliveries(
+	ctx context.Context, subscriptionID int64, events []domains.PREvent, at time.Time) error {
+	if len(events) == 0 {
+		return nil
+	}
+	eventIDs := make([]int64, 0, len(events))
+	for _, event := range events {
+		eventIDs = append(eventIDs, event.ID)
+	}
+
+	tx, err := w.database.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Printf("warning: transaction rollback failed: %v", err)
+		}
+	}()
+
+	queryQueue := `
+		INSERT INTO webhook_pending_deliveries (subscription_id, event_id, next_attempt_at)
+		SELECT $1, event_id, $3
+		FROM unnest($2::bigint[]) AS e(event_id)
+		ON CONFLICT (subscription_id, event_id) DO NOTHING
+	`
+	if _, err := tx.Exec(ctx, queryQueue, subscriptionID, eventIDs, at); err != nil {
+		return err
+	}
+
+	queryCursor := `
+		UPDATE webhook_subscriptions
+		SET last_event_id = GREATEST(last_event_id, $2)
+		WHERE id = $1
+	`
+	if _, err := tx.Exec(ctx, queryCursor, subscriptionID, slices.Max(eventIDs)); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (w *webhookRepositoryImpl) GetDueDeliveries(
+	ctx context.Context, subscriptionID int64, now time.Time, limit int) ([]domains.PendingDelivery, error) {
+	query := `
+		SELECT p.subscription_id, p.attempts, p.next_attempt_at,
+		       e.id, e.pull_request_id, e.event_type,
+		       COALESCE(e.actor, ''), COALESCE(e.reviewer_id, ''), COALESCE(e.replaced_by, ''),
+		       COALESCE(e.state, ''), COALESCE(e.reason, ''), e.created_at
+		FROM webhook_pending_deliveries p
+		JOIN pr_events e ON e.id = p.event_id
+		WHERE p.subscription_id = $1
+		  AND p.next_attempt_at <= $2
+		ORDER BY p.event_id
+		LIMIT $3
+	`
+
+	rows, err := w.database.Query(ctx, query, subscriptionID, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pending := make([]domains.PendingDelivery, 0)
+	for rows.Next() {
+		var delivery domains.PendingDelivery
+		event := &delivery.Event
+		err := rows.Scan(&delivery.SubscriptionID, &delivery.Attempts, &delivery.NextAttemptAt,
+			&event.ID, &event.PullRequestID, &event.Type,
+			&event.Actor, &event.ReviewerID, &event.ReplacedBy,
+			&event.State, &event.Reason, &event.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		pending = append(pending, delivery)
+	}
+
+	return pending, rows.Err()
+}
+
+// RecordDelivery logs an attempt and, in the same transaction, reschedules the
+// queued event when NextAttemptAt is set or drops it from the queue otherwise.
+func (w *webhookRepositoryImpl) RecordDelivery(ctx context.Context, delivery *domains.WebhookDelivery) error {
+	tx, err := w.database.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Printf("warning: transaction rollback failed: %v", err)
+		}
+	}()
+
+	queryLog := `
+		INSERT INTO webhook_deliveries (
+			subscription_id, event_id, event_type, attempt, status_code, error, success, next_attempt_at
+		)
+		VALUES ($1, $2, $3, $4, NULLIF($5, 0), NULLIF($6, ''), $7, $8)
+		RETURNING id, created_at
+	`
+	err = tx.QueryRow(ctx, queryLog,
+		delivery.SubscriptionID, delivery.EventID, delivery.EventType, delivery.Attempt,
+		delivery.StatusCode, delivery.Error, delivery.Success, delivery.NextAttemptAt,
+	).Scan(&delivery.ID, &delivery.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	if delivery.NextAttemptAt != nil {
+		queryRetry := `
+			UPDATE webhook_pending_deliveries
+			SET attempts = $3, next_attempt_at = $4
+			WHERE subscription_id = $1 AND event_id = $2
+		`
+		_, err = tx.Exec(ctx, queryRetry, delivery.SubscriptionID, delivery.EventID, delivery.Attempt, *delivery.NextAttemptAt)
+	} else {
+		queryDone := `DELETE FROM webhook_pending_deliveries WHERE subscription_id = $1 AND event_id = $2`
+		_, err = tx.Exec(ctx, queryDone, delivery.SubscriptionID, delivery.EventID)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (w *webhookRepositoryImpl) GetDeliveries(
+	ctx context.Context, subscriptionID int64, limit int) ([]domains.WebhookDelivery, error) {
+	query := `
+		SELECT id, subscription_id, event_id, event_type, attempt,
+		       COALESCE(status_code, 0), COALESCE(error, ''), success, next_attempt_at, created_at
+		FROM webhook_deliveries
+		WHERE subscription_id = $1
+		ORDER BY id DESC
+		LIMIT $2
+	`
+
+	rows, err := w.database.Query(ctx, query, subscriptionID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]domains.WebhookDelivery, 0)
+	for rows.Next() {
+		var delivery domains.WebhookDelivery
+		err := rows.Scan(&delivery.ID, &delivery.SubscriptionID, &delivery.EventID, &delivery.EventType,
+			&delivery.Attempt, &delivery.StatusCode, &delivery.Error, &delivery.Success,
+			&delivery.NextAttemptAt, &delivery.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func eventTypes(types []domains.PREventType) []string {
+	values := make([]string, 0, len(types))
+	for _, eventType := range types {
+		values = append(values, string(eventType))
+	}
+	return values
+}
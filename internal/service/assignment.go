@@ -0,0 +1,353 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"ReviewerAssignmentService/internal/codeowners"
+	"ReviewerAssignmentService/internal/domains"
+)
+
+type assignmentRequest struct {
+	teamName     string
+	settings     *domains.TeamSettings
+	exclude      []string
+	count        int
+	changedFiles []string
+	skipOwnTeam  bool
+	batch        *assignmentBatch
+}
+
+// assignmentBatch carries state across the assignments of one bulk operation:
+// reviews handed out but not yet persisted, and team lookups that do not change meanwhile.
+type assignmentBatch struct {
+	exclude    []string
+	load       map[string]int
+	settings   map[string]*domains.TeamSettings
+	candidates map[string][]domains.ReviewCandidate
+}
+
+func newAssignmentBatch(exclude []string) *assignmentBatch {
+	return &assignmentBatch{
+		exclude:    exclude,
+		load:       make(map[string]int),
+		settings:   make(map[string]*domains.TeamSettings),
+		candidates: make(map[string][]domains.ReviewCandidate),
+	}
+}
+
+type assignment struct {
+	reviewers []string
+	owners    []string
+	fallback  []string
+	policy    string
+	notes     []string
+	overLimit int
+}
+
+type reviewerSelection struct {
+	reviewers []string
+	overLimit int
+}
+
+// assign picks up to req.count reviewers: a code owner of the changed files first,
+// then members of the team, then members of its fallback teams in order.
+func (s *prServiceImpl) assign(ctx context.Context, req assignmentRequest) (*assignment, error) {
+	result := &assignment{
+		reviewers: make([]string, 0, req.count),
+		owners:    make([]string, 0, 1),
+		fallback:  make([]string, 0),
+	}
+	result.policy, _ = s.selectorFor(req.settings)
+	exclude := append([]string{}, req.exclude...)
+
+	if len(req.changedFiles) > 0 && req.count > 0 {
+		owner, owned, err := s.selectOwner(ctx, req.teamName, req.settings, req.changedFiles, exclude, req.batch)
+		if err != nil {
+			return nil, err
+		}
+		if owner != "" {
+			result.reviewers = append(result.reviewers, owner)
+			result.owners = append(result.owners, owner)
+			exclude = append(exclude, owner)
+		} else if owned {
+			result.notes = append(result.notes, noteNoOwnerAvailable)
+		}
+	}
+
+	teams := append([]string{req.teamName}, req.settings.FallbackTeams...)
+	for i, teamName := range teams {
+		missing := req.count - len(result.reviewers)
+		if missing <= 0 {
+			break
+		}
+		if i == 0 && req.skipOwnTeam {
+			continue
+		}
+
+		settings := req.settings
+		if i > 0 {
+			var err error
+			if settings, err = s.batchSettings(ctx, teamName, req.batch); err != nil {
+				return nil, err
+			}
+		}
+
+		selection, err := s.selectReviewers(ctx, teamName, settings, exclude, missing, req.batch)
+		if err != nil {
+			return nil, err
+		}
+
+		result.overLimit += selection.overLimit
+		result.reviewers = append(result.reviewers, selection.reviewers...)
+		if i > 0 {
+			result.fallback = append(result.fallback, selection.reviewers...)
+		}
+		exclude = append(exclude, selection.reviewers...)
+	}
+
+	if len(result.reviewers) < req.count && result.overLimit > 0 {
+		result.notes = append(result.notes, fmt.Sprintf(
+			"assigned %d of %d reviewers: %d candidate(s) reached the open review limit",
+			len(result.reviewers), req.count, result.overLimit,
+		))
+	}
+
+	return result, nil
+}
+
+// assignInitial picks the reviewers a PR enters review with.
+func (s *prServiceImpl) assignInitial(
+	ctx context.Context, author *domains.User, changedFiles []string) (*assignment, error) {
+	settings, err := s.teamSettings(ctx, author.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.assign(ctx, assignmentRequest{
+		teamName:     author.TeamName,
+		settings:     settings,
+		exclude:      []string{author.ID},
+		count:        settings.RequiredReviewers,
+		changedFiles: changedFiles,
+	})
+}
+
+func (a *assignment) apply(pr *domains.PullRequest) {
+	pr.AssignedReviewers = a.reviewers
+	pr.OwnerReviewers = a.owners
+	pr.FallbackReviewers = a.fallback
+	pr.AssignmentPolicy = a.policy
+	pr.AssignmentNotes = a.notes
+	pr.Reviews = pendingReviews(a.reviewers, time.Now())
+}
+
+func (s *prServiceImpl) teamSettings(ctx context.Context, teamName string) (*domains.TeamSettings, error) {
+	settings, err := s.teamRepository.GetSettings(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		settings = &domains.TeamSettings{}
+	}
+	if settings.RequiredReviewers <= 0 {
+		settings.RequiredReviewers = domains.DefaultRequiredReviewers
+	}
+	return settings, nil
+}
+
+func (s *prServiceImpl) batchSettings(
+	ctx context.Context, teamName string, batch *assignmentBatch) (*domains.TeamSettings, error) {
+	if batch == nil {
+		return s.teamSettings(ctx, teamName)
+	}
+	if settings, ok := batch.settings[teamName]; ok {
+		return settings, nil
+	}
+
+	settings, err := s.teamSettings(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	batch.settings[teamName] = settings
+	return settings, nil
+}
+
+func (s *prServiceImpl) teamCandidates(ctx context.Context,
+	teamName string, exclude []string, batch *assignmentBatch) ([]domains.ReviewCandidate, error) {
+	if batch == nil {
+		return s.userRepository.GetActiveCandidatesByTeam(ctx, teamName, exclude)
+	}
+
+	cached, ok := batch.candidates[teamName]
+	if !ok {
+		var err error
+		cached, err = s.userRepository.GetActiveCandidatesByTeam(ctx, teamName, batch.exclude)
+		if err != nil {
+			return nil, err
+		}
+		batch.candidates[teamName] = cached
+	}
+
+	candidates := make([]domains.ReviewCandidate, 0, len(cached))
+	for _, candidate := range cached {
+		if !slices.Contains(exclude, candidate.UserID) {
+			candidates = append(candidates, candidate)
+		}
+	}
+	s.rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	addLoad(candidates, batch.load)
+	return candidates, nil
+}
+
+func (s *prServiceImpl) selectReviewers(ctx context.Context, teamName string,
+	settings *domains.TeamSettings, exclude []string, count int, batch *assignmentBatch) (*reviewerSelection, error) {
+	_, selector := s.selectorFor(settings)
+
+	candidates, err := s.teamCandidates(ctx, teamName, exclude, batch)
+	if err != nil {
+		return nil, err
+	}
+
+	selection := &reviewerSelection{}
+	candidates, selection.overLimit = withinLimit(candidates, s.reviewLimit(settings))
+	selection.reviewers = selector.Select(teamName, candidates, count)
+	return selection, nil
+}
+
+// codeOwners lists the owners of changedFiles under the rules of teamName,
+// users and "@team" entries alike.
+func (s *prServiceImpl) codeOwners(ctx context.Context, teamName string, changedFiles []string) ([]string, error) {
+	if len(changedFiles) == 0 {
+		return nil, nil
+	}
+	rules, err := s.ownershipRepository.GetRules(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleset := codeowners.NewRuleset(rules)
+	owners := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, file := range changedFiles {
+		for _, owner := range ruleset.Owners(file) {
+			if !seen[owner] {
+				seen[owner] = true
+				owners = append(owners, owner)
+			}
+		}
+	}
+	return owners, nil
+}
+
+// isCodeOwner reports whether userID is one of owners or belongs to one of the owning teams.
+func (s *prServiceImpl) isCodeOwner(ctx context.Context, userID string, owners []string) (bool, error) {
+	userIDs, teamNames := codeowners.SplitOwners(owners)
+	if slices.Contains(userIDs, userID) {
+		return true, nil
+	}
+	if len(teamNames) == 0 {
+		return false, nil
+	}
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil || user == nil {
+		return false, err
+	}
+	return slices.Contains(teamNames, user.TeamName), nil
+}
+
+// selectOwner picks one code owner of changedFiles. The boolean reports whether
+// any of the files is owned at all, so callers can tell "no rules" from "no one available".
+func (s *prServiceImpl) selectOwner(ctx context.Context, teamName string, settings *domains.TeamSettings,
+	changedFiles []string, exclude []string, batch *assignmentBatch) (string, bool, error) {
+	owners, err := s.codeOwners(ctx, teamName, changedFiles)
+	if err != nil {
+		return "", false, err
+	}
+	if len(owners) == 0 {
+		return "", false, nil
+	}
+
+	userIDs, teamNames := codeowners.SplitOwners(owners)
+	candidates := make([]domains.ReviewCandidate, 0)
+	if len(userIDs) > 0 {
+		candidates, err = s.userRepository.GetActiveCandidatesByIDs(ctx, userIDs, exclude)
+		if err != nil {
+			return "", false, err
+		}
+		if batch != nil {
+			addLoad(candidates, batch.load)
+		}
+	}
+	for _, ownerTeam := range teamNames {
+		members, err := s.teamCandidates(ctx, ownerTeam, exclude, batch)
+		if err != nil {
+			return "", false, err
+		}
+		candidates = append(candidates, members...)
+	}
+
+	unique := make([]domains.ReviewCandidate, 0, len(candidates))
+	seen := make(map[string]bool)
+	for _, candidate := range candidates {
+		if !seen[candidate.UserID] {
+			seen[candidate.UserID] = true
+			unique = append(unique, candidate)
+		}
+	}
+
+	_, selector := s.selectorFor(settings)
+	eligible, _ := withinLimit(unique, s.reviewLimit(settings))
+	selected := selector.Select(teamName, eligible, 1)
+	if len(selected) == 0 {
+		return "", true, nil
+	}
+	return selected[0], true, nil
+}
+
+func (s *prServiceImpl) selectorFor(settings *domains.TeamSettings) (string, ReviewerSelector) {
+	strategy := s.defaultStrategy
+	if settings.ReviewerStrategy != "" {
+		strategy = settings.ReviewerStrategy
+	}
+	selector, ok := s.selectors[strategy]
+	if !ok {
+		strategy = s.defaultStrategy
+		selector = s.selectors[strategy]
+	}
+	return strategy, selector
+}
+
+func (s *prServiceImpl) reviewLimit(settings *domains.TeamSettings) int {
+	if settings.MaxOpenReviews != nil {
+		return *settings.MaxOpenReviews
+	}
+	return s.maxOpenReviews
+}
+
+func withinLimit(candidates []domains.ReviewCandidate, limit int) ([]domains.ReviewCandidate, int) {
+	if limit <= 0 {
+		return candidates, 0
+	}
+
+	eligible := make([]domains.ReviewCandidate, 0, len(candidates))
+	skipped := 0
+	for _, candidate := range candidates {
+		if candidate.OpenReviews >= limit {
+			skipped++
+			continue
+		}
+		eligible = append(eligible, candidate)
+	}
+	return eligible, skipped
+}
+
+func addLoad(candidates []domains.ReviewCandidate, load map[string]int) {
+	for i := range candidates {
+		candidates[i].OpenReviews += load[candidates[i].UserID]
+	}
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"ReviewerAssignmentService/internal/domains"
+	"ReviewerAssignmentService/internal/repository"
+)
+
+var (
+	ErrIdempotencyKeyReused  = errors.New("idempotency key was already used for a different request")
+	ErrIdempotencyInProgress = errors.New("a request with this idempotency key is still in progress")
+)
+
+const (
+	defaultIdempotencyWindow  = 24 * time.Hour
+	idempotencyReserveRetries = 2
+	// idempotencyLease is how long a reserved key stays claimed before its request
+	// completes, so that a request that never finished does not block retries for the whole window.
+	idempotencyLease = time.Minute
+)
+
+type idempotencyServiceImpl struct {
+	idempotencyRepository repository.IdempotencyRepository
+	window                time.Duration
+}
+
+func NewIdempotencyService(idempotencyRepository repository.IdempotencyRepository, window time.Duration) IdempotencyService {
+	if window <= 0 {
+		window = defaultIdempotencyWindow
+	}
+	return &idempotencyServiceImpl{
+		idempotencyRepository: idempotencyRepository,
+		window:                window,
+	}
+}
+
+// Begin claims key for a request. It returns nil when the caller should handle
+// the request, or the stored response when the request was already handled.
+func (s *idempotencyServiceImpl) Begin(
+	ctx context.Context, key string, endpoint string, requestHash string) (*domains.IdempotentResponse, error) {
+	for range idempotencyReserveRetries {
+		reserved, err := s.idempotencyRepository.Reserve(ctx, &domains.IdempotentResponse{
+			Key:         key,
+			Endpoint:    endpoint,
+			RequestHash: requestHash,
+			ExpiresAt:   time.Now().Add(idempotencyLease),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if reserved {
+			return nil, nil
+		}
+
+		record, err := s.idempotencyRepository.Get(ctx, key, endpoint)
+		if err != nil {
+			return nil, err
+		}
+		if record == nil {
+			// expired between Reserve and Get
+			continue
+		}
+		if record.RequestHash != requestHash {
+			return nil, ErrIdempotencyKeyReused
+		}
+		if !record.Completed {
+			return nil, ErrIdempotencyInProgress
+		}
+		return record, nil
+	}
+
+	return nil, ErrIdempotencyInProgress
+}
+
+// Complete stores the response for key and keeps it for the idempotency window.
+func (s *idempotencyServiceImpl) Complete(
+	ctx context.Context, key string, endpoint string, statusCode int, body []byte) error {
+	return s.idempotencyRepository.Complete(ctx, key, endpoint, statusCode, body, time.Now().Add(s.window))
+}
+
+// Release forgets key so that a request that failed unexpectedly can be retried.
+func (s *idempotencyServiceImpl) Release(ctx context.Context, key string, endpoint string) error {
+	return s.idempotencyRepository.Delete(ctx, key, endpoint)
+}
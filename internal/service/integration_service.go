@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ReviewerAssignmentService/internal/domains"
+	"ReviewerAssignmentService/internal/integration"
+	"ReviewerAssignmentService/internal/repository"
+)
+
+var (
+	ErrIntegrationDisabled = errors.New("integration is not configured")
+	ErrInvalidSignature    = errors.New("invalid webhook signature")
+	ErrIdentityNotFound    = errors.New("no identity mapping for login")
+	ErrUnknownProvider     = errors.New("unknown provider")
+	ErrMissingLogin        = errors.New("login is required")
+)
+
+type IntegrationConfig struct {
+	GitHubSecret string
+	GitLabToken  string
+}
+
+type integrationServiceImpl struct {
+	prService          PRService
+	prRepository       repository.PRRepository
+	userRepository     repository.UserRepository
+	identityRepository repository.IdentityRepository
+	config             IntegrationConfig
+}
+
+func NewIntegrationService(prService PRService, prRepository repository.PRRepository,
+	userRepository repository.UserRepository, identityRepository repository.IdentityRepository,
+	config IntegrationConfig) IntegrationService {
+	return &integrationServiceImpl{
+		prService:          prService,
+		prRepository:       prRepository,
+		userRepository:     userRepository,
+		identityRepository: identityRepository,
+		config:             config,
+	}
+}
+
+func (s *integrationServiceImpl) HandleGitHub(
+	ctx context.Context, eventType string, signature string, body []byte) (*domains.IntegrationResult, error) {
+	if s.config.GitHubSecret == "" {
+		return nil, ErrIntegrationDisabled
+	}
+	if !integration.VerifyGitHubSignature(s.config.GitHubSecret, body, signature) {
+		return nil, ErrInvalidSignature
+	}
+	if eventType != integration.GitHubPullRequestEvent {
+		return &domains.IntegrationResult{Provider: domains.ProviderGitHub, Ignored: true}, nil
+	}
+
+	event, err := integration.ParseGitHub(body)
+	if err != nil {
+		return nil, err
+	}
+	return s.apply(ctx, event)
+}
+
+func (s *integrationServiceImpl) HandleGitLab(
+	ctx context.Context, eventType string, token string, body []byte) (*domains.IntegrationResult, error) {
+	if s.config.GitLabToken == "" {
+		return nil, ErrIntegrationDisabled
+	}
+	if !integration.VerifyGitLabToken(s.config.GitLabToken, token) {
+		return nil, ErrInvalidSignature
+	}
+	if eventType != integration.GitLabMergeRequestEvent {
+		return &domains.IntegrationResult{Provider: domains.ProviderGitLab, Ignored: true}, nil
+	}
+
+	event, err := integration.ParseGitLab(body)
+	if err != nil {
+		return nil, err
+	}
+	return s.apply(ctx, event)
+}
+
+// apply replays a provider event through PRService. Events that were already
+// applied, such as a redelivered "opened", are reported as ignored.
+func (s *integrationServiceImpl) apply(
+	ctx context.Context, event *domains.ExternalPREvent) (*domains.IntegrationResult, error) {
+	result := &domains.IntegrationResult{
+		Provider:      event.Provider,
+		Action:        event.Action,
+		PullRequestID: event.PullRequestID,
+	}
+	if event.Action == "" {
+		result.Ignored = true
+		return result, nil
+	}
+
+	if domains.ActorFromContext(ctx) == "" && event.SenderLogin != "" {
+		actor, err := s.identityRepository.GetUserID(ctx, event.Provider, event.SenderLogin)
+		if err != nil {
+			return nil, err
+		}
+		if actor == "" {
+			actor = fmt.Sprintf("%s:%s", event.Provider, event.SenderLogin)
+		}
+		ctx = domains.WithActor(ctx, actor)
+	}
+
+	var (
+		pr  *domains.PullRequest
+		err error
+	)
+	switch event.Action {
+	case domains.ExternalOpened:
+		exists, err := s.prRepository.Exists(ctx, event.PullRequestID)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			result.Ignored = true
+			return result, nil
+		}
+
+		authorID, err := s.identityRepository.GetUserID(ctx, event.Provider, event.AuthorLogin)
+		if err != nil {
+			return nil, err
+		}
+		if authorID == "" {
+			return nil, fmt.Errorf("%w: %s", ErrIdentityNotFound, event.AuthorLogin)
+		}
+
+		pr, err = s.prService.CreatePR(ctx, domains.PullRequestInput{
+			ID:       event.PullRequestID,
+			Name:     event.Title,
+			AuthorID: authorID,
+			Draft:    event.Draft,
+		})
+		if err != nil {
+			return nil, err
+		}
+	case domains.ExternalReady:
+		pr, err = s.prService.MarkReady(ctx, event.PullRequestID, nil)
+	case domains.ExternalMerged:
+		pr, err = s.mirrorMerge(ctx, event)
+	case domains.ExternalClosed:
+		pr, err = s.prService.ClosePR(ctx, event.PullRequestID)
+	case domains.ExternalReopened:
+		pr, err = s.prService.ReopenPR(ctx, event.PullRequestID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result.PullRequest = pr
+	return result, nil
+}
+
+// mirrorMerge records a merge that already happened on the provider. When the
+// merge policy is not met it is recorded as a forced merge so the bypass is audited.
+func (s *integrationServiceImpl) mirrorMerge(
+	ctx context.Context, event *domains.ExternalPREvent) (*domains.PullRequest, error) {
+	pr, err := s.prService.MergePR(ctx, event.PullRequestID)
+	if !errors.Is(err, ErrMergeBlocked) {
+		return pr, err
+	}
+
+	forcedBy := domains.ActorFromContext(ctx)
+	if forcedBy == "" {
+		forcedBy = string(event.Provider)
+	}
+	ctx = context.WithValue(ctx, providerMergeKey{}, true)
+	return s.prService.ForceMergePR(ctx, event.PullRequestID, &domains.MergeOverride{
+		ForcedBy: forcedBy,
+		Reason:   fmt.Sprintf("merged on %s", event.Provider),
+	})
+}
+
+func (s *integrationServiceImpl) AddIdentity(
+	ctx context.Context, identity *domains.ExternalIdentity) (*domains.ExternalIdentity, error) {
+	switch identity.Provider {
+	case domains.ProviderGitHub, domains.ProviderGitLab:
+	default:
+		return nil, ErrUnknownProvider
+	}
+	if identity.Login == "" {
+		return nil, ErrMissingLogin
+	}
+
+	exists, err := s.userRepository.Exists(ctx, identity.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrUserFound
+	}
+
+	if err := s.identityRepository.Upsert(ctx, identity); err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+func (s *integrationServiceImpl) GetIdentities(ctx context.Context, userID string) ([]domains.ExternalIdentity, error) {
+	exists, err := s.userRepository.Exists(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrUserFound
+	}
+
+	return s.identityRepository.GetByUser(ctx, userID)
+}
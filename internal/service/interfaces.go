@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"ReviewerAssignmentService/internal/domains"
 )
@@ -9,16 +10,55 @@ import (
 type TeamService interface {
 	CreateTeam(ctx context.Context, team *domains.Team) (*domains.Team, error)
 	GetTeam(ctx context.Context, name string) (*domains.Team, error)
+	SetOwnershipRules(ctx context.Context, teamName string, content string) ([]domains.OwnershipRule, error)
+	GetOwnershipRules(ctx context.Context, teamName string) ([]domains.OwnershipRule, error)
 }
 
 type UserService interface {
 	SetIsActive(ctx context.Context, userID string, isActive bool) error
 	GetUserPRs(ctx context.Context, userID string) ([]*domains.PullRequestShort, error)
 	GetGlobalStats(ctx context.Context) (*domains.GlobalStats, error)
+	AddUnavailability(ctx context.Context, period *domains.Unavailability) (*domains.Unavailability, error)
+	GetUnavailability(ctx context.Context, userID string) ([]domains.Unavailability, error)
+	DeleteUnavailability(ctx context.Context, id int64) error
 }
 
 type PRService interface {
 	CreatePR(ctx context.Context, input domains.PullRequestInput) (*domains.PullRequest, error)
 	MergePR(ctx context.Context, prID string) (*domains.PullRequest, error)
-	UpdateReviewer(ctx context.Context, prID string, oldReviewerID string) (*domains.PullRequest, string, error)
+	ForceMergePR(ctx context.Context, prID string, override *domains.MergeOverride) (*domains.PullRequest, error)
+	UpdateReviewer(ctx context.Context, prID string, oldReviewerID string, newReviewerID string) (*domains.PullRequest, string, error)
+	DeactivateUser(ctx context.Context, userID string) (*domains.ReassignmentReport, error)
+	DeactivateTeam(ctx context.Context, teamName string) (*domains.TeamDeactivation, error)
+	MarkReady(ctx context.Context, prID string, changedFiles []string) (*domains.PullRequest, error)
+	ClosePR(ctx context.Context, prID string) (*domains.PullRequest, error)
+	ReopenPR(ctx context.Context, prID string) (*domains.PullRequest, error)
+	GetHistory(ctx context.Context, prID string) ([]domains.PREvent, error)
+	AddReviewer(ctx context.Context, prID string, reviewerID string) (*domains.PullRequest, error)
+	RemoveReviewer(ctx context.Context, prID string, reviewerID string) (*domains.PullRequest, error)
+	SubmitReview(ctx context.Context, prID string, reviewerID string, state domains.ReviewState) (*domains.PullRequest, error)
+	MarkOverdueReviews(ctx context.Context, now time.Time) (int, error)
+	EscalateStaleReviews(ctx context.Context, now time.Time) (int, error)
+	GetOverdueReviews(ctx context.Context, teamName string) ([]domains.PendingReview, error)
+}
+
+type WebhookService interface {
+	CreateSubscription(ctx context.Context, sub *domains.WebhookSubscription) (*domains.WebhookSubscription, error)
+	GetSubscriptions(ctx context.Context) ([]domains.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id int64) error
+	GetDeliveries(ctx context.Context, subscriptionID int64) ([]domains.WebhookDelivery, error)
+	DispatchPending(ctx context.Context, now time.Time) (int, error)
+}
+
+type IntegrationService interface {
+	HandleGitHub(ctx context.Context, eventType string, signature string, body []byte) (*domains.IntegrationResult, error)
+	HandleGitLab(ctx context.Context, eventType string, token string, body []byte) (*domains.IntegrationResult, error)
+	AddIdentity(ctx context.Context, identity *domains.ExternalIdentity) (*domains.ExternalIdentity, error)
+	GetIdentities(ctx context.Context, userID string) ([]domains.ExternalIdentity, error)
+}
+
+type IdempotencyService interface {
+	Begin(ctx context.Context, key string, endpoint string, requestHash string) (*domains.IdempotentResponse, error)
+	Complete(ctx context.Context, key string, endpoint string, statusCode int, body []byte) error
+	Release(ctx context.Context, key string, endpoint string) error
 }
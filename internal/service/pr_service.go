@@ -3,6 +3,10 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
 	"time"
 
 	"ReviewerAssignmentService/internal/domains"
@@ -11,26 +15,97 @@ import (
 
 var (
 	ErrPRNotFound               = errors.New("pull request not found")
+	ErrPRExists                 = errors.New("PR id already exists")
 	ErrPRMerged                 = errors.New("cannot edit merged PR")
 	ErrReviewerNotAssigned      = errors.New("reviewer is not assigned to this PR")
 	ErrNoCandidates             = errors.New("no available candidates for assignment")
 	ErrAuthorNotFound           = errors.New("author not found")
 	ErrOriginalReviewerNotFound = errors.New("original reviewer user not found")
+	ErrReviewLimitReached       = errors.New("all candidates reached the open review limit")
+	ErrInvalidReviewState       = errors.New("unknown review state")
+	ErrMergeBlocked             = errors.New("merge requirements not met")
+	ErrOverrideActorRequired    = errors.New("forced merge requires forced_by")
+	ErrOverrideForbidden        = errors.New("actor is not allowed to force a merge")
+	ErrReviewerIsAuthor         = errors.New("author cannot review own PR")
+	ErrAlreadyAssigned          = errors.New("reviewer is already assigned to this PR")
+	ErrNotTeammate              = errors.New("reviewer is not a member of the team")
+	ErrReviewerUnavailable      = errors.New("reviewer is inactive or unavailable")
+	ErrMinReviewers             = errors.New("PR would have fewer than the required number of reviewers")
+	ErrConcurrentModification   = errors.New("pull request was modified concurrently")
 )
 
+const (
+	noteNoOwnerAvailable = "no available code owner for the changed files"
+	policyManual         = "manual"
+)
+
+type AssignmentConfig struct {
+	Strategy       string
+	MaxOpenReviews int
+	// Random breaks ties between equally suitable candidates; nil means a randomly seeded source.
+	Random rand.Source
+	// MergeAdmins may force a merge past the merge policy; with none, forced merges are refused.
+	MergeAdmins []string
+	// WorkingHours are counted against review_sla_hours and escalation_hours; zero means 09:00-18:00 UTC.
+	WorkingHours WorkingHours
+}
+
 type prServiceImpl struct {
-	prRepository   repository.PRRepository
-	userRepository repository.UserRepository
+	prRepository        repository.PRRepository
+	userRepository      repository.UserRepository
+	teamRepository      repository.TeamRepository
+	ownershipRepository repository.OwnershipRepository
+	unitOfWork          repository.UnitOfWork
+
+	defaultStrategy string
+	maxOpenReviews  int
+	selectors       map[string]ReviewerSelector
+	rand            *rand.Rand
+	mergeAdmins     []string
+	workingHours    WorkingHours
 }
 
-func NewPRService(prRepository repository.PRRepository, userRepository repository.UserRepository) PRService {
+func NewPRService(
+	prRepository repository.PRRepository,
+	userRepository repository.UserRepository,
+	teamRepository repository.TeamRepository,
+	ownershipRepository repository.OwnershipRepository,
+	unitOfWork repository.UnitOfWork,
+	assignment AssignmentConfig,
+) PRService {
+	rng := newRand(assignment.Random)
+	selectors := make(map[string]ReviewerSelector)
+	for _, strategy := range []string{StrategyRandom, StrategyRoundRobin, StrategyLeastLoaded, StrategyWeighted} {
+		selectors[strategy], _ = newReviewerSelector(strategy, rng)
+	}
+
+	defaultStrategy := assignment.Strategy
+	if _, ok := selectors[defaultStrategy]; !ok {
+		defaultStrategy = StrategyLeastLoaded
+	}
+
 	return &prServiceImpl{
-		prRepository:   prRepository,
-		userRepository: userRepository,
+		prRepository:        prRepository,
+		userRepository:      userRepository,
+		teamRepository:      teamRepository,
+		ownershipRepository: ownershipRepository,
+		unitOfWork:          unitOfWork,
+		defaultStrategy:     defaultStrategy,
+		maxOpenReviews:      assignment.MaxOpenReviews,
+		selectors:           selectors,
+		rand:                rng,
+		mergeAdmins:         assignment.MergeAdmins,
+		workingHours:        assignment.WorkingHours.withDefaults(),
 	}
 }
 
 func (s *prServiceImpl) CreatePR(ctx context.Context, input domains.PullRequestInput) (*domains.PullRequest, error) {
+	return inTx(ctx, s, func(tx *prServiceImpl) (*domains.PullRequest, error) {
+		return tx.createPR(ctx, input)
+	})
+}
+
+func (s *prServiceImpl) createPR(ctx context.Context, input domains.PullRequestInput) (*domains.PullRequest, error) {
 	author, err := s.userRepository.GetByID(ctx, input.AuthorID)
 	if err != nil {
 		return nil, err
@@ -39,20 +114,29 @@ func (s *prServiceImpl) CreatePR(ctx context.Context, input domains.PullRequestI
 		return nil, ErrAuthorNotFound
 	}
 
-	candidateIDs, err := s.userRepository.GetRandomActiveUsersByTeam(ctx, author.TeamName, author.ID, 2)
-	if err != nil {
-		return nil, err
-	}
-
 	pr := &domains.PullRequest{
 		ID:                input.ID,
 		Name:              input.Name,
 		AuthorID:          input.AuthorID,
-		Status:            domains.PRStatusOpen,
-		AssignedReviewers: candidateIDs,
+		Status:            domains.PRStatusDraft,
+		AssignedReviewers: []string{},
+		ChangedFiles:      input.ChangedFiles,
+		Reviews:           []domains.Review{},
+	}
+
+	if !input.Draft {
+		result, err := s.assignInitial(ctx, author, input.ChangedFiles)
+		if err != nil {
+			return nil, err
+		}
+		pr.Status = domains.PRStatusOpen
+		result.apply(pr)
 	}
 
 	if err := s.prRepository.Create(ctx, pr); err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return nil, ErrPRExists
+		}
 		return nil, err
 	}
 
@@ -60,6 +144,12 @@ func (s *prServiceImpl) CreatePR(ctx context.Context, input domains.PullRequestI
 }
 
 func (s *prServiceImpl) MergePR(ctx context.Context, prID string) (*domains.PullRequest, error) {
+	return inTx(ctx, s, func(tx *prServiceImpl) (*domains.PullRequest, error) {
+		return tx.mergePR(ctx, prID)
+	})
+}
+
+func (s *prServiceImpl) mergePR(ctx context.Context, prID string) (*domains.PullRequest, error) {
 	pr, err := s.prRepository.GetByID(ctx, prID)
 	if err != nil {
 		return nil, err
@@ -71,20 +161,176 @@ func (s *prServiceImpl) MergePR(ctx context.Context, prID string) (*domains.Pull
 	if pr.Status == domains.PRStatusMerged {
 		return pr, nil
 	}
+	if err := checkTransition(pr.Status, domains.PRStatusMerged); err != nil {
+		return nil, err
+	}
+
+	unmet, err := s.unmetMergeConditions(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+	if len(unmet) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrMergeBlocked, strings.Join(unmet, "; "))
+	}
 
 	pr.Status = domains.PRStatusMerged
-	if err := s.prRepository.Update(ctx, pr); err != nil {
+	if err := conflict(s.prRepository.Update(ctx, pr)); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	pr.MergedAt = &now
+
+	return pr, nil
+}
+
+// ForceMergePR merges regardless of the merge policy and records who bypassed
+// which conditions.
+func (s *prServiceImpl) ForceMergePR(
+	ctx context.Context, prID string, override *domains.MergeOverride) (*domains.PullRequest, error) {
+	return inTx(ctx, s, func(tx *prServiceImpl) (*domains.PullRequest, error) {
+		return tx.forceMergePR(ctx, prID, override)
+	})
+}
+
+func (s *prServiceImpl) forceMergePR(
+	ctx context.Context, prID string, override *domains.MergeOverride) (*domains.PullRequest, error) {
+	if override.ForcedBy == "" {
+		return nil, ErrOverrideActorRequired
+	}
+	if err := s.authorizeOverride(ctx, override); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.prRepository.GetByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if pr == nil {
+		return nil, ErrPRNotFound
+	}
+
+	if pr.Status == domains.PRStatusMerged {
+		return pr, nil
+	}
+	if err := checkTransition(pr.Status, domains.PRStatusMerged); err != nil {
+		return nil, err
+	}
+
+	unmet, err := s.unmetMergeConditions(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+	override.PullRequestID = pr.ID
+	override.UnmetConditions = unmet
+
+	if err := conflict(s.prRepository.MergeWithOverride(ctx, pr, override)); err != nil {
 		return nil, err
 	}
 
 	now := time.Now()
+	pr.Status = domains.PRStatusMerged
 	pr.MergedAt = &now
 
 	return pr, nil
 }
 
-func (s *prServiceImpl) UpdateReviewer(
-	ctx context.Context, prID string, oldReviewerID string) (*domains.PullRequest, string, error) {
+// providerMergeKey marks a context in which a merge that already happened on a
+// verified provider is mirrored; such merges need no merge admin.
+type providerMergeKey struct{}
+
+// authorizeOverride lets only merge admins force a merge, and only in their own name.
+// The admin is the request's actor; forced_by is client-supplied and only has to match it.
+func (s *prServiceImpl) authorizeOverride(ctx context.Context, override *domains.MergeOverride) error {
+	if mirrored, _ := ctx.Value(providerMergeKey{}).(bool); mirrored {
+		return nil
+	}
+	actor := domains.ActorFromContext(ctx)
+	if actor == "" || !slices.Contains(s.mergeAdmins, actor) {
+		return ErrOverrideForbidden
+	}
+	if actor != override.ForcedBy {
+		return ErrOverrideForbidden
+	}
+	return nil
+}
+
+// unmetMergeConditions checks pr against the merge policy of its author's team.
+func (s *prServiceImpl) unmetMergeConditions(ctx context.Context, pr *domains.PullRequest) ([]string, error) {
+	policy := domains.MergePolicy{}
+	author, err := s.userRepository.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	if author != nil {
+		settings, err := s.teamSettings(ctx, author.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		policy = settings.MergePolicy
+	}
+
+	// Owners come from the rules, not from pr.OwnerReviewers, so that replacing
+	// the assigned owner does not lift the requirement.
+	var owners []string
+	if policy.RequireOwnerApproval && author != nil {
+		owners, err = s.codeOwners(ctx, author.TeamName, pr.ChangedFiles)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	approvals := 0
+	ownerApproved := false
+	changesRequested := make([]string, 0)
+	for _, review := range pr.Reviews {
+		switch review.State {
+		case domains.ReviewStateApproved:
+			approvals++
+			if !ownerApproved && len(owners) > 0 {
+				ownerApproved, err = s.isCodeOwner(ctx, review.ReviewerID, owners)
+				if err != nil {
+					return nil, err
+				}
+			}
+		case domains.ReviewStateChangesRequested:
+			changesRequested = append(changesRequested, review.ReviewerID)
+		}
+	}
+
+	unmet := make([]string, 0)
+	if approvals < policy.MinApprovals {
+		unmet = append(unmet, fmt.Sprintf("%d of %d required approvals", approvals, policy.MinApprovals))
+	}
+	if len(changesRequested) > 0 {
+		unmet = append(unmet, "changes requested by "+strings.Join(changesRequested, ", "))
+	}
+	if len(owners) > 0 && !ownerApproved {
+		unmet = append(unmet, "no approval from a code owner")
+	}
+	return unmet, nil
+}
+
+// UpdateReviewer replaces oldReviewerID with newReviewerID, or with a reviewer
+// picked from the old reviewer's team when newReviewerID is empty.
+func (s *prServiceImpl) UpdateReviewer(ctx context.Context,
+	prID string, oldReviewerID string, newReviewerID string) (*domains.PullRequest, string, error) {
+	var replacedBy string
+	pr, err := inTx(ctx, s, func(tx *prServiceImpl) (*domains.PullRequest, error) {
+		pr, newID, err := tx.replaceReviewer(ctx, prID, oldReviewerID, newReviewerID, domains.ReplacementReassigned, nil)
+		replacedBy = newID
+		return pr, err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return pr, replacedBy, nil
+}
+
+// replaceReviewer backs UpdateReviewer; reason is recorded on the replacement and
+// exclude lists users that must not be picked in addition to the author and current reviewers.
+func (s *prServiceImpl) replaceReviewer(ctx context.Context, prID string,
+	oldReviewerID string, newReviewerID string, reason string, exclude []string) (*domains.PullRequest, string, error) {
 	pr, err := s.prRepository.GetByID(ctx, prID)
 	if err != nil {
 		return nil, "", err
@@ -93,8 +339,8 @@ func (s *prServiceImpl) UpdateReviewer(
 		return nil, "", ErrPRNotFound
 	}
 
-	if pr.Status == domains.PRStatusMerged {
-		return nil, "", ErrPRMerged
+	if err := requireInReview(pr); err != nil {
+		return nil, "", err
 	}
 
 	idx := -1
@@ -116,39 +362,422 @@ func (s *prServiceImpl) UpdateReviewer(
 		return nil, "", ErrOriginalReviewerNotFound
 	}
 
-	candidates, err := s.userRepository.GetRandomActiveUsersByTeam(ctx, oldUser.TeamName, oldReviewerID, 5)
+	settings, err := s.teamSettings(ctx, oldUser.TeamName)
 	if err != nil {
 		return nil, "", err
 	}
 
-	var newReviewerID string
-	for _, candID := range candidates {
-		if candID == pr.AuthorID {
-			continue
+	if newReviewerID != "" {
+		teams := append([]string{oldUser.TeamName}, settings.FallbackTeams...)
+		newUser, err := s.checkReviewer(ctx, pr, newReviewerID, teams)
+		if err != nil {
+			return nil, "", err
 		}
 
-		alreadyAssigned := false
-		for _, assigned := range pr.AssignedReviewers {
-			if assigned == candID {
-				alreadyAssigned = true
-				break
-			}
+		pr.FallbackReviewers = []string{}
+		if newUser.TeamName != oldUser.TeamName {
+			pr.FallbackReviewers = []string{newReviewerID}
 		}
-		if !alreadyAssigned {
-			newReviewerID = candID
-			break
+		pr.AssignmentPolicy = policyManual
+	} else {
+		result, err := s.assign(ctx, assignmentRequest{
+			teamName: oldUser.TeamName,
+			settings: settings,
+			exclude:  append(append([]string{pr.AuthorID}, pr.AssignedReviewers...), exclude...),
+			count:    1,
+		})
+		if err != nil {
+			return nil, "", err
 		}
-	}
 
-	if newReviewerID == "" {
-		return nil, "", ErrNoCandidates
+		if len(result.reviewers) == 0 {
+			if result.overLimit > 0 {
+				return nil, "", ErrReviewLimitReached
+			}
+			return nil, "", ErrNoCandidates
+		}
+
+		newReviewerID = result.reviewers[0]
+		pr.FallbackReviewers = result.fallback
+		pr.AssignmentPolicy = result.policy
 	}
 
 	pr.AssignedReviewers[idx] = newReviewerID
+	pr.ChangeReason = reason
+	pr.OwnerReviewers = removeID(pr.OwnerReviewers, oldReviewerID)
 
-	if err := s.prRepository.Update(ctx, pr); err != nil {
+	if err := conflict(s.prRepository.Update(ctx, pr)); err != nil {
 		return nil, "", err
 	}
 
+	now := time.Now()
+	for i := range pr.Reviews {
+		if pr.Reviews[i].ReviewerID == oldReviewerID {
+			pr.Reviews[i] = domains.Review{ReviewerID: newReviewerID, State: domains.ReviewStatePending, UpdatedAt: &now}
+		}
+	}
+
 	return pr, newReviewerID, nil
 }
+
+// AddReviewer assigns a reviewer chosen by the author; it must be an available
+// member of the author's team.
+func (s *prServiceImpl) AddReviewer(
+	ctx context.Context, prID string, reviewerID string) (*domains.PullRequest, error) {
+	return inTx(ctx, s, func(tx *prServiceImpl) (*domains.PullRequest, error) {
+		return tx.addReviewer(ctx, prID, reviewerID)
+	})
+}
+
+func (s *prServiceImpl) addReviewer(
+	ctx context.Context, prID string, reviewerID string) (*domains.PullRequest, error) {
+	pr, err := s.prRepository.GetByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if pr == nil {
+		return nil, ErrPRNotFound
+	}
+	if err := requireInReview(pr); err != nil {
+		return nil, err
+	}
+
+	author, err := s.userRepository.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	if author == nil {
+		return nil, ErrAuthorNotFound
+	}
+
+	if _, err := s.checkReviewer(ctx, pr, reviewerID, []string{author.TeamName}); err != nil {
+		return nil, err
+	}
+
+	pr.AssignedReviewers = append(pr.AssignedReviewers, reviewerID)
+	if err := conflict(s.prRepository.Update(ctx, pr)); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	pr.Reviews = append(pr.Reviews, domains.Review{
+		ReviewerID: reviewerID, State: domains.ReviewStatePending, UpdatedAt: &now,
+	})
+
+	return pr, nil
+}
+
+// RemoveReviewer unassigns a reviewer as long as the PR keeps the number of
+// reviewers its author's team requires.
+func (s *prServiceImpl) RemoveReviewer(
+	ctx context.Context, prID string, reviewerID string) (*domains.PullRequest, error) {
+	return inTx(ctx, s, func(tx *prServiceImpl) (*domains.PullRequest, error) {
+		return tx.removeReviewer(ctx, prID, reviewerID)
+	})
+}
+
+func (s *prServiceImpl) removeReviewer(
+	ctx context.Context, prID string, reviewerID string) (*domains.PullRequest, error) {
+	pr, err := s.prRepository.GetByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if pr == nil {
+		return nil, ErrPRNotFound
+	}
+	if err := requireInReview(pr); err != nil {
+		return nil, err
+	}
+	if !slices.Contains(pr.AssignedReviewers, reviewerID) {
+		return nil, ErrReviewerNotAssigned
+	}
+
+	author, err := s.userRepository.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	if author == nil {
+		return nil, ErrAuthorNotFound
+	}
+
+	settings, err := s.teamSettings(ctx, author.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	if len(pr.AssignedReviewers)-1 < settings.RequiredReviewers {
+		return nil, fmt.Errorf("%w: %d required", ErrMinReviewers, settings.RequiredReviewers)
+	}
+
+	pr.AssignedReviewers = removeID(pr.AssignedReviewers, reviewerID)
+	pr.OwnerReviewers = removeID(pr.OwnerReviewers, reviewerID)
+	pr.ChangeReason = domains.ReplacementRemoved
+	if err := conflict(s.prRepository.Update(ctx, pr)); err != nil {
+		return nil, err
+	}
+
+	reviews := make([]domains.Review, 0, len(pr.Reviews))
+	for _, review := range pr.Reviews {
+		if review.ReviewerID != reviewerID {
+			reviews = append(reviews, review)
+		}
+	}
+	pr.Reviews = reviews
+
+	return pr, nil
+}
+
+// checkReviewer validates a reviewer picked by hand rather than by a selector:
+// the reviewer must belong to one of teams and fit that team's review limit.
+func (s *prServiceImpl) checkReviewer(ctx context.Context,
+	pr *domains.PullRequest, reviewerID string, teams []string) (*domains.User, error) {
+	if reviewerID == pr.AuthorID {
+		return nil, ErrReviewerIsAuthor
+	}
+	if slices.Contains(pr.AssignedReviewers, reviewerID) {
+		return nil, ErrAlreadyAssigned
+	}
+
+	user, err := s.userRepository.GetByID(ctx, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserFound
+	}
+	if !slices.Contains(teams, user.TeamName) {
+		return nil, ErrNotTeammate
+	}
+
+	candidates, err := s.userRepository.GetActiveCandidatesByIDs(ctx, []string{reviewerID}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, ErrReviewerUnavailable
+	}
+
+	settings, err := s.teamSettings(ctx, user.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	if _, overLimit := withinLimit(candidates, s.reviewLimit(settings)); overLimit > 0 {
+		return nil, ErrReviewLimitReached
+	}
+
+	return user, nil
+}
+
+func (s *prServiceImpl) SubmitReview(ctx context.Context,
+	prID string, reviewerID string, state domains.ReviewState) (*domains.PullRequest, error) {
+	return inTx(ctx, s, func(tx *prServiceImpl) (*domains.PullRequest, error) {
+		return tx.submitReview(ctx, prID, reviewerID, state)
+	})
+}
+
+func (s *prServiceImpl) submitReview(ctx context.Context,
+	prID string, reviewerID string, state domains.ReviewState) (*domains.PullRequest, error) {
+	// PENDING is set by the service on assignment, never submitted by the reviewer.
+	if state != domains.ReviewStateApproved && state != domains.ReviewStateChangesRequested {
+		return nil, ErrInvalidReviewState
+	}
+
+	pr, err := s.prRepository.GetByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if pr == nil {
+		return nil, ErrPRNotFound
+	}
+	if err := requireInReview(pr); err != nil {
+		return nil, err
+	}
+	if !slices.Contains(pr.AssignedReviewers, reviewerID) {
+		return nil, ErrReviewerNotAssigned
+	}
+
+	if err := conflict(s.prRepository.SetReviewState(ctx, prID, reviewerID, state)); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range pr.Reviews {
+		if pr.Reviews[i].ReviewerID == reviewerID {
+			pr.Reviews[i].State = state
+			pr.Reviews[i].UpdatedAt = &now
+		}
+	}
+
+	return pr, nil
+}
+
+// MarkReady takes a draft out of draft and assigns its reviewers.
+func (s *prServiceImpl) MarkReady(
+	ctx context.Context, prID string, changedFiles []string) (*domains.PullRequest, error) {
+	return inTx(ctx, s, func(tx *prServiceImpl) (*domains.PullRequest, error) {
+		return tx.markReady(ctx, prID, changedFiles)
+	})
+}
+
+func (s *prServiceImpl) markReady(
+	ctx context.Context, prID string, changedFiles []string) (*domains.PullRequest, error) {
+	pr, err := s.prRepository.GetByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if pr == nil {
+		return nil, ErrPRNotFound
+	}
+
+	if err := checkTransition(pr.Status, domains.PRStatusOpen); err != nil {
+		return nil, err
+	}
+
+	author, err := s.userRepository.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	if author == nil {
+		return nil, ErrAuthorNotFound
+	}
+
+	if len(changedFiles) > 0 {
+		pr.ChangedFiles = changedFiles
+	}
+	result, err := s.assignInitial(ctx, author, pr.ChangedFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	pr.Status = domains.PRStatusOpen
+	result.apply(pr)
+
+	if err := conflict(s.prRepository.Update(ctx, pr)); err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+func (s *prServiceImpl) ClosePR(ctx context.Context, prID string) (*domains.PullRequest, error) {
+	return inTx(ctx, s, func(tx *prServiceImpl) (*domains.PullRequest, error) {
+		return tx.closePR(ctx, prID)
+	})
+}
+
+func (s *prServiceImpl) closePR(ctx context.Context, prID string) (*domains.PullRequest, error) {
+	pr, err := s.prRepository.GetByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if pr == nil {
+		return nil, ErrPRNotFound
+	}
+
+	if pr.Status == domains.PRStatusClosed {
+		return pr, nil
+	}
+	if err := checkTransition(pr.Status, domains.PRStatusClosed); err != nil {
+		return nil, err
+	}
+
+	pr.Status = domains.PRStatusClosed
+	pr.AssignedReviewers = []string{}
+	pr.OwnerReviewers = []string{}
+	pr.Reviews = []domains.Review{}
+	if err := conflict(s.prRepository.Update(ctx, pr)); err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+// ReopenPR brings a closed PR back into review with a freshly assigned set of reviewers.
+func (s *prServiceImpl) ReopenPR(ctx context.Context, prID string) (*domains.PullRequest, error) {
+	return inTx(ctx, s, func(tx *prServiceImpl) (*domains.PullRequest, error) {
+		return tx.reopenPR(ctx, prID)
+	})
+}
+
+func (s *prServiceImpl) reopenPR(ctx context.Context, prID string) (*domains.PullRequest, error) {
+	pr, err := s.prRepository.GetByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if pr == nil {
+		return nil, ErrPRNotFound
+	}
+
+	if err := checkTransition(pr.Status, domains.PRStatusReopened); err != nil {
+		return nil, err
+	}
+
+	author, err := s.userRepository.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	if author == nil {
+		return nil, ErrAuthorNotFound
+	}
+
+	result, err := s.assignInitial(ctx, author, pr.ChangedFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	pr.Status = domains.PRStatusReopened
+	result.apply(pr)
+
+	if err := conflict(s.prRepository.Update(ctx, pr)); err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+func (s *prServiceImpl) GetHistory(ctx context.Context, prID string) ([]domains.PREvent, error) {
+	exists, err := s.prRepository.Exists(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrPRNotFound
+	}
+
+	return s.prRepository.GetEvents(ctx, prID)
+}
+
+func pendingReviews(reviewers []string, at time.Time) []domains.Review {
+	reviews := make([]domains.Review, 0, len(reviewers))
+	for _, reviewer := range reviewers {
+		reviews = append(reviews, domains.Review{ReviewerID: reviewer, State: domains.ReviewStatePending, UpdatedAt: &at})
+	}
+	return reviews
+}
+
+// conflict reports a write that lost a race with another change to the same PR
+// as ErrConcurrentModification; the caller may retry with a fresh read.
+func conflict(err error) error {
+	if errors.Is(err, repository.ErrConflict) {
+		return ErrConcurrentModification
+	}
+	return err
+}
+
+func removeID(ids []string, id string) []string {
+	result := make([]string, 0, len(ids))
+	for _, existing := range ids {
+		if existing != id {
+			result = append(result, existing)
+		}
+	}
+	return result
+}
+
+func appendUnique(ids []string, more ...string) []string {
+	for _, id := range more {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"ReviewerAssignmentService/internal/domains"
+)
+
+var (
+	ErrPRClosed          = errors.New("PR is closed")
+	ErrPRDraft           = errors.New("PR is a draft")
+	ErrInvalidTransition = errors.New("invalid PR status transition")
+)
+
+var prTransitions = map[domains.PRStatus][]domains.PRStatus{
+	domains.PRStatusDraft:    {domains.PRStatusOpen, domains.PRStatusClosed},
+	domains.PRStatusOpen:     {domains.PRStatusMerged, domains.PRStatusClosed},
+	domains.PRStatusReopened: {domains.PRStatusMerged, domains.PRStatusClosed},
+	domains.PRStatusClosed:   {domains.PRStatusReopened},
+}
+
+func checkTransition(from, to domains.PRStatus) error {
+	if slices.Contains(prTransitions[from], to) {
+		return nil
+	}
+	if err := statusError(from); err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, from, to)
+}
+
+// requireInReview rejects reviewer changes on PRs that are not waiting for review.
+func requireInReview(pr *domains.PullRequest) error {
+	if pr.Status.InReview() {
+		return nil
+	}
+	if err := statusError(pr.Status); err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: %s", ErrInvalidTransition, pr.Status)
+}
+
+func statusError(status domains.PRStatus) error {
+	switch status {
+	case domains.PRStatusMerged:
+		return ErrPRMerged
+	case domains.PRStatusClosed:
+		return ErrPRClosed
+	case domains.PRStatusDraft:
+		return ErrPRDraft
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+
+	"ReviewerAssignmentService/internal/domains"
+)
+
+func (s *prServiceImpl) DeactivateUser(ctx context.Context, userID string) (*domains.ReassignmentReport, error) {
+	return inTx(ctx, s, func(tx *prServiceImpl) (*domains.ReassignmentReport, error) {
+		return tx.deactivateUser(ctx, userID)
+	})
+}
+
+func (s *prServiceImpl) deactivateUser(ctx context.Context, userID string) (*domains.ReassignmentReport, error) {
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserFound
+	}
+
+	settings, err := s.teamSettings(ctx, user.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	report, changed, err := s.reassignDeparting(ctx, []string{userID}, map[string]assignmentRequest{
+		userID: {teamName: user.TeamName, settings: settings},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepository.DeactivateUsers(ctx, []string{userID}, changed); err != nil {
+		return nil, conflict(err)
+	}
+	return report, nil
+}
+
+func (s *prServiceImpl) DeactivateTeam(ctx context.Context, teamName string) (*domains.TeamDeactivation, error) {
+	return inTx(ctx, s, func(tx *prServiceImpl) (*domains.TeamDeactivation, error) {
+		return tx.deactivateTeam(ctx, teamName)
+	})
+}
+
+func (s *prServiceImpl) deactivateTeam(ctx context.Context, teamName string) (*domains.TeamDeactivation, error) {
+	team, err := s.teamRepository.GetByName(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, ErrTeamNotFound
+	}
+
+	settings, err := s.teamSettings(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]string, 0, len(team.Members))
+	sources := make(map[string]assignmentRequest, len(team.Members))
+	for _, member := range team.Members {
+		userIDs = append(userIDs, member.UserID)
+		sources[member.UserID] = assignmentRequest{teamName: teamName, settings: settings, skipOwnTeam: true}
+	}
+
+	report, changed, err := s.reassignDeparting(ctx, userIDs, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepository.DeactivateTeamMembers(ctx, teamName, changed); err != nil {
+		return nil, conflict(err)
+	}
+
+	return &domains.TeamDeactivation{
+		TeamName:         teamName,
+		DeactivatedUsers: userIDs,
+		Reassignments:    report,
+	}, nil
+}
+
+// reassignDeparting replaces every departing reviewer on open PRs, drawing each
+// replacement according to sources[reviewer]. It returns the PRs that changed so
+// the caller can persist them together with the deactivation.
+func (s *prServiceImpl) reassignDeparting(ctx context.Context, userIDs []string,
+	sources map[string]assignmentRequest) (*domains.ReassignmentReport, []*domains.PullRequest, error) {
+	prs, err := s.prRepository.GetOpenByReviewers(ctx, userIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	report := &domains.ReassignmentReport{
+		Reassigned: make([]domains.Reassignment, 0),
+		Unassigned: make([]domains.Reassignment, 0),
+	}
+	batch := newAssignmentBatch(userIDs)
+	changed := make([]*domains.PullRequest, 0, len(prs))
+
+	for _, pr := range prs {
+		modified := false
+		for idx, reviewer := range pr.AssignedReviewers {
+			req, departing := sources[reviewer]
+			if !departing {
+				continue
+			}
+
+			req.exclude = appendUnique(append([]string{pr.AuthorID}, pr.AssignedReviewers...), userIDs...)
+			req.count = 1
+			req.batch = batch
+
+			result, err := s.assign(ctx, req)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if len(result.reviewers) == 0 {
+				reason := ErrNoCandidates.Error()
+				if result.overLimit > 0 {
+					reason = ErrReviewLimitReached.Error()
+				}
+				report.Unassigned = append(report.Unassigned, domains.Reassignment{
+					PullRequestID: pr.ID,
+					OldUserID:     reviewer,
+					Reason:        reason,
+				})
+				continue
+			}
+
+			newReviewerID := result.reviewers[0]
+			pr.AssignedReviewers[idx] = newReviewerID
+			pr.OwnerReviewers = removeID(pr.OwnerReviewers, reviewer)
+			batch.load[newReviewerID]++
+			modified = true
+
+			report.Reassigned = append(report.Reassigned, domains.Reassignment{
+				PullRequestID: pr.ID,
+				OldUserID:     reviewer,
+				NewUserID:     newReviewerID,
+			})
+		}
+
+		if modified {
+			changed = append(changed, pr)
+		}
+	}
+
+	return report, changed, nil
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"ReviewerAssignmentService/internal/domains"
+)
+
+var ErrInvalidWorkingHours = errors.New("working hours must satisfy 0 <= start < end <= 24")
+
+const (
+	defaultWorkdayStart = 9
+	defaultWorkdayEnd   = 18
+)
+
+// MarkOverdueReviews flags pending reviews that have waited longer than the
+// SLA of the author's team and returns how many were newly flagged.
+func (s *prServiceImpl) MarkOverdueReviews(ctx context.Context, now time.Time) (int, error) {
+	pending, err := s.prRepository.GetPendingReviews(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	overdue := make([]domains.PendingReview, 0)
+	for _, review := range pending {
+		if review.OverdueAt == nil && s.slaExceeded(review, now) {
+			overdue = append(overdue, review)
+		}
+	}
+	if len(overdue) == 0 {
+		return 0, nil
+	}
+
+	if err := s.prRepository.MarkOverdue(ctx, overdue, now); err != nil {
+		return 0, err
+	}
+	return len(overdue), nil
+}
+
+// GetOverdueReviews lists reviews flagged as overdue, optionally limited to
+// PRs authored by teamName, oldest first.
+func (s *prServiceImpl) GetOverdueReviews(ctx context.Context, teamName string) ([]domains.PendingReview, error) {
+	if teamName != "" {
+		exists, err := s.teamRepository.Exists(ctx, teamName)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, ErrTeamNotFound
+		}
+	}
+
+	overdue, err := s.prRepository.GetOverdueReviews(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range overdue {
+		overdue[i].AgeHours = s.workingHours.between(overdue[i].AssignedAt, now)
+	}
+	return overdue, nil
+}
+
+// EscalateStaleReviews replaces reviewers who have not given a verdict within
+// their team's escalation_hours. A reviewer replaced this way is never picked
+// again for the same PR. Returns the number of replacements made.
+func (s *prServiceImpl) EscalateStaleReviews(ctx context.Context, now time.Time) (int, error) {
+	pending, err := s.prRepository.GetPendingReviews(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	escalated := 0
+	for _, review := range pending {
+		if review.EscalationHours <= 0 || s.workingHours.between(review.AssignedAt, now) < float64(review.EscalationHours) {
+			continue
+		}
+
+		_, err := inTx(ctx, s, func(tx *prServiceImpl) (*domains.PullRequest, error) {
+			timedOut, err := tx.prRepository.GetTimedOutReviewers(ctx, review.PullRequestID)
+			if err != nil {
+				return nil, err
+			}
+			pr, _, err := tx.replaceReviewer(ctx, review.PullRequestID, review.ReviewerID, "",
+				domains.ReplacementSLATimeout, timedOut)
+			return pr, err
+		})
+		if err != nil {
+			if isSkippableEscalation(err) {
+				slog.Warn("Escalation skipped",
+					"pull_request_id", review.PullRequestID, "reviewer_id", review.ReviewerID, "error", err)
+				continue
+			}
+			return escalated, err
+		}
+		escalated++
+	}
+
+	return escalated, nil
+}
+
+// isSkippableEscalation reports errors caused by the state of a single PR, which
+// must not stop escalation of the others.
+func isSkippableEscalation(err error) bool {
+	return errors.Is(err, ErrNoCandidates) ||
+		errors.Is(err, ErrReviewLimitReached) ||
+		errors.Is(err, ErrReviewerNotAssigned) ||
+		errors.Is(err, ErrOriginalReviewerNotFound) ||
+		errors.Is(err, ErrPRNotFound) ||
+		errors.Is(err, ErrPRMerged) ||
+		errors.Is(err, ErrPRClosed) ||
+		errors.Is(err, ErrPRDraft) ||
+		errors.Is(err, ErrConcurrentModification)
+}
+
+// WorkingHours is the business day review SLAs and escalations are counted in:
+// from StartHour to EndHour of every Monday to Friday in Location.
+type WorkingHours struct {
+	StartHour int
+	EndHour   int
+	Location  *time.Location
+}
+
+// Validate reports whether the day runs forward within 0..24 hours.
+func (w WorkingHours) Validate() error {
+	if w.StartHour < 0 || w.EndHour > 24 || w.StartHour >= w.EndHour {
+		return ErrInvalidWorkingHours
+	}
+	return nil
+}
+
+func (w WorkingHours) withDefaults() WorkingHours {
+	if w.StartHour == 0 && w.EndHour == 0 {
+		w.StartHour, w.EndHour = defaultWorkdayStart, defaultWorkdayEnd
+	}
+	if w.Location == nil {
+		w.Location = time.UTC
+	}
+	return w
+}
+
+func (s *prServiceImpl) slaExceeded(review domains.PendingReview, now time.Time) bool {
+	return review.SLAHours > 0 && s.workingHours.between(review.AssignedAt, now) >= float64(review.SLAHours)
+}
+
+// between counts the hours between from and to that fall within working hours.
+func (w WorkingHours) between(from, to time.Time) float64 {
+	from, to = from.In(w.Location), to.In(w.Location)
+	var total time.Duration
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, w.Location)
+	for ; day.Before(to); day = day.AddDate(0, 0, 1) {
+		if weekday := day.Weekday(); weekday == time.Saturday || weekday == time.Sunday {
+			continue
+		}
+		start := time.Date(day.Year(), day.Month(), day.Day(), w.StartHour, 0, 0, 0, w.Location)
+		end := time.Date(day.Year(), day.Month(), day.Day(), w.EndHour, 0, 0, 0, w.Location)
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if end.After(start) {
+			total += end.Sub(start)
+		}
+	}
+	return total.Hours()
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"errors"
+	"math/rand/v2"
+	"sort"
+	"sync"
+
+	"ReviewerAssignmentService/internal/domains"
+)
+
+const (
+	StrategyRandom      = "random"
+	StrategyRoundRobin  = "round_robin"
+	StrategyLeastLoaded = "least_loaded"
+	StrategyWeighted    = "weighted"
+)
+
+var ErrUnknownStrategy = errors.New("unknown reviewer selection strategy")
+
+type ReviewerSelector interface {
+	Select(teamName string, candidates []domains.ReviewCandidate, limit int) []string
+}
+
+func NewReviewerSelector(strategy string) (ReviewerSelector, error) {
+	return newReviewerSelector(strategy, newRand(nil))
+}
+
+func newReviewerSelector(strategy string, rng *rand.Rand) (ReviewerSelector, error) {
+	switch strategy {
+	case StrategyRandom:
+		return randomSelector{}, nil
+	case StrategyRoundRobin:
+		return &roundRobinSelector{last: make(map[string]string)}, nil
+	case StrategyLeastLoaded:
+		return leastLoadedSelector{}, nil
+	case StrategyWeighted:
+		return weightedSelector{rng: rng}, nil
+	default:
+		return nil, ErrUnknownStrategy
+	}
+}
+
+func ValidateStrategy(strategy string) error {
+	if strategy == "" {
+		return nil
+	}
+	_, err := NewReviewerSelector(strategy)
+	return err
+}
+
+// randomSelector relies on the repository returning candidates in random order.
+type randomSelector struct{}
+
+func (randomSelector) Select(_ string, candidates []domains.ReviewCandidate, limit int) []string {
+	return firstIDs(candidates, limit)
+}
+
+// roundRobinSelector keeps its cursor in memory, so every replica rotates independently.
+type roundRobinSelector struct {
+	mu   sync.Mutex
+	last map[string]string
+}
+
+func (s *roundRobinSelector) Select(teamName string, candidates []domains.ReviewCandidate, limit int) []string {
+	ordered := make([]domains.ReviewCandidate, len(candidates))
+	copy(ordered, candidates)
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].UserID < ordered[j].UserID
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	start := sort.Search(len(ordered), func(i int) bool {
+		return ordered[i].UserID > s.last[teamName]
+	})
+	rotated := append(ordered[start:], ordered[:start]...)
+
+	selected := firstIDs(rotated, limit)
+	if len(selected) > 0 {
+		s.last[teamName] = selected[len(selected)-1]
+	}
+	return selected
+}
+
+// leastLoadedSelector breaks ties by repository order, which is random.
+type leastLoadedSelector struct{}
+
+func (leastLoadedSelector) Select(_ string, candidates []domains.ReviewCandidate, limit int) []string {
+	ordered := make([]domains.ReviewCandidate, len(candidates))
+	copy(ordered, candidates)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].OpenReviews < ordered[j].OpenReviews
+	})
+	return firstIDs(ordered, limit)
+}
+
+// weightedSelector draws without replacement, weighting each candidate by 1/(1+open reviews).
+type weightedSelector struct {
+	rng *rand.Rand
+}
+
+func (s weightedSelector) Select(_ string, candidates []domains.ReviewCandidate, limit int) []string {
+	pool := make([]domains.ReviewCandidate, len(candidates))
+	copy(pool, candidates)
+
+	selected := make([]string, 0, limit)
+	for len(selected) < limit && len(pool) > 0 {
+		total := 0.0
+		for _, c := range pool {
+			total += candidateWeight(c)
+		}
+
+		point := s.rng.Float64() * total
+		idx := len(pool) - 1
+		for i, c := range pool {
+			point -= candidateWeight(c)
+			if point < 0 {
+				idx = i
+				break
+			}
+		}
+
+		selected = append(selected, pool[idx].UserID)
+		pool = append(pool[:idx], pool[idx+1:]...)
+	}
+	return selected
+}
+
+func candidateWeight(c domains.ReviewCandidate) float64 {
+	return 1 / float64(1+c.OpenReviews)
+}
+
+func firstIDs(candidates []domains.ReviewCandidate, limit int) []string {
+	if limit > len(candidates) {
+		limit = len(candidates)
+	}
+	ids := make([]string, 0, limit)
+	for _, c := range candidates[:limit] {
+		ids = append(ids, c.UserID)
+	}
+	return ids
+}
+
+// lockedSource makes a rand.Source safe to share between concurrent requests.
+type lockedSource struct {
+	mu     sync.Mutex
+	source rand.Source
+}
+
+func (s *lockedSource) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.source.Uint64()
+}
+
+// newRand returns a generator drawing from source, or from a randomly seeded one if source is nil.
+func newRand(source rand.Source) *rand.Rand {
+	if source == nil {
+		source = rand.NewPCG(rand.Uint64(), rand.Uint64())
+	}
+	return rand.New(&lockedSource{source: source})
+}
@@ -3,22 +3,64 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 
+	"ReviewerAssignmentService/internal/codeowners"
 	"ReviewerAssignmentService/internal/domains"
 	"ReviewerAssignmentService/internal/repository"
 )
 
-var ErrTeamExists = errors.New("team already exists")
+var (
+	ErrTeamExists         = errors.New("team already exists")
+	ErrInvalidReviewLimit = errors.New("max_open_reviews must not be negative")
+	ErrInvalidReviewCount = errors.New("required_reviewers must be positive")
+	ErrTeamNotFound       = errors.New("team not found")
+	ErrUnknownOwner       = errors.New("unknown owner")
+	ErrInvalidFallback    = errors.New("invalid fallback team")
+	ErrInvalidApprovals   = errors.New("min_approvals must be between 0 and required_reviewers")
+	ErrInvalidSLA         = errors.New("review_sla_hours and escalation_hours must not be negative")
+)
 
 type teamServiceImpl struct {
-	teamRepository repository.TeamRepository
+	teamRepository      repository.TeamRepository
+	userRepository      repository.UserRepository
+	ownershipRepository repository.OwnershipRepository
 }
 
-func NewTeamService(repo repository.TeamRepository) TeamService {
-	return &teamServiceImpl{teamRepository: repo}
+func NewTeamService(
+	repo repository.TeamRepository,
+	userRepository repository.UserRepository,
+	ownershipRepository repository.OwnershipRepository,
+) TeamService {
+	return &teamServiceImpl{
+		teamRepository:      repo,
+		userRepository:      userRepository,
+		ownershipRepository: ownershipRepository,
+	}
 }
 
 func (s *teamServiceImpl) CreateTeam(ctx context.Context, team *domains.Team) (*domains.Team, error) {
+	if err := ValidateStrategy(team.ReviewerStrategy); err != nil {
+		return nil, err
+	}
+	if team.MaxOpenReviews != nil && *team.MaxOpenReviews < 0 {
+		return nil, ErrInvalidReviewLimit
+	}
+	if team.RequiredReviewers < 0 {
+		return nil, ErrInvalidReviewCount
+	}
+	// 0 means the field was omitted.
+	if team.RequiredReviewers == 0 {
+		team.RequiredReviewers = domains.DefaultRequiredReviewers
+	}
+	if team.MinApprovals < 0 || team.MinApprovals > team.RequiredReviewers {
+		return nil, ErrInvalidApprovals
+	}
+	if team.ReviewSLAHours < 0 || team.EscalationHours < 0 {
+		return nil, ErrInvalidSLA
+	}
+
 	exists, err := s.teamRepository.Exists(ctx, team.Name)
 	if err != nil {
 		return nil, err
@@ -27,6 +69,22 @@ func (s *teamServiceImpl) CreateTeam(ctx context.Context, team *domains.Team) (*
 		return nil, ErrTeamExists
 	}
 
+	seen := make(map[string]bool)
+	for _, fallbackTeam := range team.FallbackTeams {
+		if fallbackTeam == team.Name || seen[fallbackTeam] {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidFallback, fallbackTeam)
+		}
+		seen[fallbackTeam] = true
+
+		exists, err := s.teamRepository.Exists(ctx, fallbackTeam)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidFallback, fallbackTeam)
+		}
+	}
+
 	if err := s.teamRepository.Create(ctx, team); err != nil {
 		return nil, err
 	}
@@ -36,3 +94,59 @@ func (s *teamServiceImpl) CreateTeam(ctx context.Context, team *domains.Team) (*
 func (s *teamServiceImpl) GetTeam(ctx context.Context, name string) (*domains.Team, error) {
 	return s.teamRepository.GetByName(ctx, name)
 }
+
+func (s *teamServiceImpl) SetOwnershipRules(
+	ctx context.Context, teamName string, content string) ([]domains.OwnershipRule, error) {
+	exists, err := s.teamRepository.Exists(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrTeamNotFound
+	}
+
+	rules, err := codeowners.Parse(content)
+	if err != nil {
+		return nil, err
+	}
+
+	checked := make(map[string]bool)
+	for _, rule := range rules {
+		for _, owner := range rule.Owners {
+			if checked[owner] {
+				continue
+			}
+
+			var exists bool
+			if ownerTeam, isTeam := strings.CutPrefix(owner, codeowners.TeamOwnerPrefix); isTeam {
+				exists, err = s.teamRepository.Exists(ctx, ownerTeam)
+			} else {
+				exists, err = s.userRepository.Exists(ctx, owner)
+			}
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				return nil, fmt.Errorf("%w: %s", ErrUnknownOwner, owner)
+			}
+			checked[owner] = true
+		}
+	}
+
+	if err := s.ownershipRepository.ReplaceRules(ctx, teamName, rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (s *teamServiceImpl) GetOwnershipRules(ctx context.Context, teamName string) ([]domains.OwnershipRule, error) {
+	exists, err := s.teamRepository.Exists(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrTeamNotFound
+	}
+
+	return s.ownershipRepository.GetRules(ctx, teamName)
+}
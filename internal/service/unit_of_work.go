@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+
+	"ReviewerAssignmentService/internal/repository"
+)
+
+// inTx runs fn on a copy of s whose repositories share one transaction, so
+// every read and write fn makes commits or rolls back together.
+func inTx[T any](ctx context.Context, s *prServiceImpl, fn func(tx *prServiceImpl) (T, error)) (T, error) {
+	var result T
+	err := s.unitOfWork.WithTx(ctx, func(repos repository.Repositories) error {
+		tx := *s
+		tx.prRepository = repos.PR
+		tx.userRepository = repos.User
+		tx.teamRepository = repos.Team
+		tx.ownershipRepository = repos.Ownership
+
+		var err error
+		result, err = fn(&tx)
+		return err
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return result, nil
+}
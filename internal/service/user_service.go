@@ -8,7 +8,12 @@ import (
 	"ReviewerAssignmentService/internal/repository"
 )
 
-var ErrUserFound = errors.New("user not found")
+var (
+	ErrUserFound              = errors.New("user not found")
+	ErrInvalidPeriod          = errors.New("ends_at must be after starts_at")
+	ErrUnknownUnavailability  = errors.New("unknown unavailability reason")
+	ErrUnavailabilityNotFound = errors.New("unavailability period not found")
+)
 
 type userServiceImpl struct {
 	userRepository repository.UserRepository
@@ -54,3 +59,51 @@ func (s *userServiceImpl) GetGlobalStats(ctx context.Context) (*domains.GlobalSt
 		TotalPRs:   prsCount,
 	}, nil
 }
+
+func (s *userServiceImpl) AddUnavailability(
+	ctx context.Context, period *domains.Unavailability) (*domains.Unavailability, error) {
+	switch period.Reason {
+	case domains.UnavailabilityVacation, domains.UnavailabilitySickLeave, domains.UnavailabilityOther:
+	default:
+		return nil, ErrUnknownUnavailability
+	}
+	if !period.EndsAt.After(period.StartsAt) {
+		return nil, ErrInvalidPeriod
+	}
+
+	exists, err := s.userRepository.Exists(ctx, period.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrUserFound
+	}
+
+	if err := s.userRepository.AddUnavailability(ctx, period); err != nil {
+		return nil, err
+	}
+	return period, nil
+}
+
+func (s *userServiceImpl) GetUnavailability(ctx context.Context, userID string) ([]domains.Unavailability, error) {
+	exists, err := s.userRepository.Exists(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrUserFound
+	}
+
+	return s.userRepository.GetUnavailability(ctx, userID)
+}
+
+func (s *userServiceImpl) DeleteUnavailability(ctx context.Context, id int64) error {
+	deleted, err := s.userRepository.DeleteUnavailability(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrUnavailabilityNotFound
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"ReviewerAssignmentService/internal/domains"
+	"ReviewerAssignmentService/internal/repository"
+)
+
+var (
+	ErrInvalidWebhookURL    = errors.New("webhook url must be an absolute http(s) url")
+	ErrMissingWebhookSecret = errors.New("webhook secret is required")
+	ErrUnknownWebhookEvent  = errors.New("unknown webhook event")
+	ErrSubscriptionNotFound = errors.New("webhook subscription not found")
+)
+
+const (
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+
+	defaultWebhookAttempts = 5
+	defaultWebhookBackoff  = time.Second
+	defaultWebhookTimeout  = 10 * time.Second
+
+	webhookBatchSize   = 100
+	deliveryLogLimit   = 100
+	signaturePrefix    = "sha256="
+	maxBackoffExponent = 10
+)
+
+type WebhookConfig struct {
+	MaxAttempts int
+	BaseBackoff time.Duration
+	Timeout     time.Duration
+}
+
+type webhookServiceImpl struct {
+	webhookRepository repository.WebhookRepository
+	client            *http.Client
+	maxAttempts       int
+	baseBackoff       time.Duration
+}
+
+func NewWebhookService(webhookRepository repository.WebhookRepository, config WebhookConfig) WebhookService {
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaultWebhookAttempts
+	}
+	if config.BaseBackoff <= 0 {
+		config.BaseBackoff = defaultWebhookBackoff
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaultWebhookTimeout
+	}
+
+	return &webhookServiceImpl{
+		webhookRepository: webhookRepository,
+		client:            &http.Client{Timeout: config.Timeout},
+		maxAttempts:       config.MaxAttempts,
+		baseBackoff:       config.BaseBackoff,
+	}
+}
+
+// WebhookSignature is the value of WebhookSignatureHeader for body signed with secret.
+func WebhookSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *webhookServiceImpl) CreateSubscription(
+	ctx context.Context, sub *domains.WebhookSubscription) (*domains.WebhookSubscription, error) {
+	target, err := url.Parse(sub.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return nil, ErrInvalidWebhookURL
+	}
+	if sub.Secret == "" {
+		return nil, ErrMissingWebhookSecret
+	}
+
+	if len(sub.Events) == 0 {
+		sub.Events = slices.Clone(domains.WebhookEvents)
+	}
+	events := make([]domains.PREventType, 0, len(sub.Events))
+	for _, event := range sub.Events {
+		if !slices.Contains(domains.WebhookEvents, event) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownWebhookEvent, event)
+		}
+		if !slices.Contains(events, event) {
+			events = append(events, event)
+		}
+	}
+	sub.Events = events
+
+	if err := s.webhookRepository.CreateSubscription(ctx, sub); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+func (s *webhookServiceImpl) GetSubscriptions(ctx context.Context) ([]domains.WebhookSubscription, error) {
+	subs, err := s.webhookRepository.GetSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range subs {
+		subs[i].Secret = ""
+	}
+	return subs, nil
+}
+
+func (s *webhookServiceImpl) DeleteSubscription(ctx context.Context, id int64) error {
+	deleted, err := s.webhookRepository.DeleteSubscription(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrSubscriptionNotFound
+	}
+	return nil
+}
+
+func (s *webhookServiceImpl) GetDeliveries(ctx context.Context, subscriptionID int64) ([]domains.WebhookDelivery, error) {
+	return s.webhookRepository.GetDeliveries(ctx, subscriptionID, deliveryLogLimit)
+}
+
+// DispatchPending queues the events each subscription has not seen yet and
+// attempts the deliveries that are due at now, returning how many were
+// accepted by receivers. Subscriptions are dispatched independently, so a slow
+// or failing receiver does not hold back the others. A failed attempt is
+// retried on a later call once its backoff has elapsed; an event whose retries
+// are exhausted leaves the queue and its failed attempts stay in the delivery log.
+func (s *webhookServiceImpl) DispatchPending(ctx context.Context, now time.Time) (int, error) {
+	subs, err := s.webhookRepository.GetSubscriptions(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		wg        sync.WaitGroup
+		delivered atomic.Int64
+		errs      = make([]error, len(subs))
+	)
+	for i, sub := range subs {
+		wg.Go(func() {
+			n, err := s.dispatch(ctx, sub, now)
+			delivered.Add(int64(n))
+			errs[i] = err
+		})
+	}
+	wg.Wait()
+
+	return int(delivered.Load()), errors.Join(errs...)
+}
+
+// dispatch queues new events for sub and attempts its due deliveries in event order.
+func (s *webhookServiceImpl) dispatch(ctx context.Context, sub domains.WebhookSubscription, now time.Time) (int, error) {
+	events, err := s.webhookRepository.GetEventsAfter(ctx, sub.LastEventID, sub.Events, webhookBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.webhookRepository.QueueDeliveries(ctx, sub.ID, events, now); err != nil {
+		return 0, err
+	}
+
+	due, err := s.webhookRepository.GetDueDeliveries(ctx, sub.ID, now, webhookBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, pending := range due {
+		ok, err := s.attempt(ctx, sub, pending, now)
+		if err != nil {
+			return delivered, err
+		}
+		if ok {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+// attempt posts a queued event to sub once and records the attempt. A failure
+// with retries left is rescheduled after an exponential backoff. The returned
+// error is only set when the attempt could not be recorded.
+func (s *webhookServiceImpl) attempt(
+	ctx context.Context, sub domains.WebhookSubscription, pending domains.PendingDelivery, now time.Time) (bool, error) {
+	body, err := json.Marshal(pending.Event)
+	if err != nil {
+		return false, err
+	}
+
+	delivery := &domains.WebhookDelivery{
+		SubscriptionID: sub.ID,
+		EventID:        pending.Event.ID,
+		EventType:      pending.Event.Type,
+		Attempt:        pending.Attempts + 1,
+	}
+	delivery.StatusCode, err = s.send(ctx, sub, pending.Event, body)
+	if err != nil {
+		delivery.Error = err.Error()
+	} else {
+		delivery.Success = true
+	}
+	if !delivery.Success && delivery.Attempt < s.maxAttempts {
+		next := now.Add(s.backoff(delivery.Attempt))
+		delivery.NextAttemptAt = &next
+	}
+
+	if err := s.webhookRepository.RecordDelivery(ctx, delivery); err != nil {
+		return false, err
+	}
+	return delivery.Success, nil
+}
+
+func (s *webhookServiceImpl) send(
+	ctx context.Context, sub domains.WebhookSubscription, event domains.PREvent, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, string(event.Type))
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatInt(event.ID, 10))
+	req.Header.Set(WebhookSignatureHeader, WebhookSignature(sub.Secret, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func (s *webhookServiceImpl) backoff(retry int) time.Duration {
+	return s.baseBackoff << min(retry-1, maxBackoffExponent)
+}
@@ -0,0 +1,125 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	domains "ReviewerAssignmentService/internal/domains"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IdempotencyRepository is an autogenerated mock type for the IdempotencyRepository type
+type IdempotencyRepository struct {
+	mock.Mock
+}
+
+// Complete provides a mock function with given fields: ctx, key, endpoint, statusCode, body, expiresAt
+func (_m *IdempotencyRepository) Complete(ctx context.Context, key string, endpoint string, statusCode int, body []byte, expiresAt time.Time) error {
+	ret := _m.Called(ctx, key, endpoint, statusCode, body, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, []byte, time.Time) error); ok {
+		r0 = rf(ctx, key, endpoint, statusCode, body, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, key, endpoint
+func (_m *IdempotencyRepository) Delete(ctx context.Context, key string, endpoint string) error {
+	ret := _m.Called(ctx, key, endpoint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, key, endpoint)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, key, endpoint
+func (_m *IdempotencyRepository) Get(ctx context.Context, key string, endpoint string) (*domains.IdempotentResponse, error) {
+	ret := _m.Called(ctx, key, endpoint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domains.IdempotentResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domains.IdempotentResponse, error)); ok {
+		return rf(ctx, key, endpoint)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domains.IdempotentResponse); ok {
+		r0 = rf(ctx, key, endpoint)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.IdempotentResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, key, endpoint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reserve provides a mock function with given fields: ctx, record
+func (_m *IdempotencyRepository) Reserve(ctx context.Context, record *domains.IdempotentResponse) (bool, error) {
+	ret := _m.Called(ctx, record)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reserve")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.IdempotentResponse) (bool, error)); ok {
+		return rf(ctx, record)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.IdempotentResponse) bool); ok {
+		r0 = rf(ctx, record)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.IdempotentResponse) error); ok {
+		r1 = rf(ctx, record)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIdempotencyRepository creates a new instance of IdempotencyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIdempotencyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IdempotencyRepository {
+	mock := &IdempotencyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	domains "ReviewerAssignmentService/internal/domains"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IdempotencyService is an autogenerated mock type for the IdempotencyService type
+type IdempotencyService struct {
+	mock.Mock
+}
+
+// Begin provides a mock function with given fields: ctx, key, endpoint, requestHash
+func (_m *IdempotencyService) Begin(ctx context.Context, key string, endpoint string, requestHash string) (*domains.IdempotentResponse, error) {
+	ret := _m.Called(ctx, key, endpoint, requestHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Begin")
+	}
+
+	var r0 *domains.IdempotentResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*domains.IdempotentResponse, error)); ok {
+		return rf(ctx, key, endpoint, requestHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *domains.IdempotentResponse); ok {
+		r0 = rf(ctx, key, endpoint, requestHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.IdempotentResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, key, endpoint, requestHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Complete provides a mock function with given fields: ctx, key, endpoint, statusCode, body
+func (_m *IdempotencyService) Complete(ctx context.Context, key string, endpoint string, statusCode int, body []byte) error {
+	ret := _m.Called(ctx, key, endpoint, statusCode, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, []byte) error); ok {
+		r0 = rf(ctx, key, endpoint, statusCode, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Release provides a mock function with given fields: ctx, key, endpoint
+func (_m *IdempotencyService) Release(ctx context.Context, key string, endpoint string) error {
+	ret := _m.Called(ctx, key, endpoint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Release")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, key, endpoint)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIdempotencyService creates a new instance of IdempotencyService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIdempotencyService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IdempotencyService {
+	mock := &IdempotencyService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
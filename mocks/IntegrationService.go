@@ -0,0 +1,149 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	domains "ReviewerAssignmentService/internal/domains"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IntegrationService is an autogenerated mock type for the IntegrationService type
+type IntegrationService struct {
+	mock.Mock
+}
+
+// AddIdentity provides a mock function with given fields: ctx, identity
+func (_m *IntegrationService) AddIdentity(ctx context.Context, identity *domains.ExternalIdentity) (*domains.ExternalIdentity, error) {
+	ret := _m.Called(ctx, identity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddIdentity")
+	}
+
+	var r0 *domains.ExternalIdentity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.ExternalIdentity) (*domains.ExternalIdentity, error)); ok {
+		return rf(ctx, identity)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.ExternalIdentity) *domains.ExternalIdentity); ok {
+		r0 = rf(ctx, identity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.ExternalIdentity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.ExternalIdentity) error); ok {
+		r1 = rf(ctx, identity)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetIdentities provides a mock function with given fields: ctx, userID
+func (_m *IntegrationService) GetIdentities(ctx context.Context, userID string) ([]domains.ExternalIdentity, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIdentities")
+	}
+
+	var r0 []domains.ExternalIdentity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domains.ExternalIdentity, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domains.ExternalIdentity); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domains.ExternalIdentity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HandleGitHub provides a mock function with given fields: ctx, eventType, signature, body
+func (_m *IntegrationService) HandleGitHub(ctx context.Context, eventType string, signature string, body []byte) (*domains.IntegrationResult, error) {
+	ret := _m.Called(ctx, eventType, signature, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleGitHub")
+	}
+
+	var r0 *domains.IntegrationResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []byte) (*domains.IntegrationResult, error)); ok {
+		return rf(ctx, eventType, signature, body)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []byte) *domains.IntegrationResult); ok {
+		r0 = rf(ctx, eventType, signature, body)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.IntegrationResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []byte) error); ok {
+		r1 = rf(ctx, eventType, signature, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HandleGitLab provides a mock function with given fields: ctx, eventType, token, body
+func (_m *IntegrationService) HandleGitLab(ctx context.Context, eventType string, token string, body []byte) (*domains.IntegrationResult, error) {
+	ret := _m.Called(ctx, eventType, token, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleGitLab")
+	}
+
+	var r0 *domains.IntegrationResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []byte) (*domains.IntegrationResult, error)); ok {
+		return rf(ctx, eventType, token, body)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []byte) *domains.IntegrationResult); ok {
+		r0 = rf(ctx, eventType, token, body)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.IntegrationResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []byte) error); ok {
+		r1 = rf(ctx, eventType, token, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIntegrationService creates a new instance of IntegrationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIntegrationService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IntegrationService {
+	mock := &IntegrationService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,77 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	domains "ReviewerAssignmentService/internal/domains"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OwnershipRepository is an autogenerated mock type for the OwnershipRepository type
+type OwnershipRepository struct {
+	mock.Mock
+}
+
+// GetRules provides a mock function with given fields: ctx, teamName
+func (_m *OwnershipRepository) GetRules(ctx context.Context, teamName string) ([]domains.OwnershipRule, error) {
+	ret := _m.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRules")
+	}
+
+	var r0 []domains.OwnershipRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domains.OwnershipRule, error)); ok {
+		return rf(ctx, teamName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domains.OwnershipRule); ok {
+		r0 = rf(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domains.OwnershipRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReplaceRules provides a mock function with given fields: ctx, teamName, rules
+func (_m *OwnershipRepository) ReplaceRules(ctx context.Context, teamName string, rules []domains.OwnershipRule) error {
+	ret := _m.Called(ctx, teamName, rules)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceRules")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []domains.OwnershipRule) error); ok {
+		r0 = rf(ctx, teamName, rules)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewOwnershipRepository creates a new instance of OwnershipRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOwnershipRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OwnershipRepository {
+	mock := &OwnershipRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -7,6 +7,8 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // PRRepository is an autogenerated mock type for the PRRepository type
@@ -148,6 +150,210 @@ func (_m *PRRepository) GetByReviewer(ctx context.Context, reviewerID string) ([
 	return r0, r1
 }
 
+// GetEvents provides a mock function with given fields: ctx, prID
+func (_m *PRRepository) GetEvents(ctx context.Context, prID string) ([]domains.PREvent, error) {
+	ret := _m.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEvents")
+	}
+
+	var r0 []domains.PREvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domains.PREvent, error)); ok {
+		return rf(ctx, prID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domains.PREvent); ok {
+		r0 = rf(ctx, prID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domains.PREvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOpenByReviewers provides a mock function with given fields: ctx, reviewerIDs
+func (_m *PRRepository) GetOpenByReviewers(ctx context.Context, reviewerIDs []string) ([]*domains.PullRequest, error) {
+	ret := _m.Called(ctx, reviewerIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOpenByReviewers")
+	}
+
+	var r0 []*domains.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*domains.PullRequest, error)); ok {
+		return rf(ctx, reviewerIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []*domains.PullRequest); ok {
+		r0 = rf(ctx, reviewerIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domains.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, reviewerIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOverdueReviews provides a mock function with given fields: ctx, teamName
+func (_m *PRRepository) GetOverdueReviews(ctx context.Context, teamName string) ([]domains.PendingReview, error) {
+	ret := _m.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOverdueReviews")
+	}
+
+	var r0 []domains.PendingReview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domains.PendingReview, error)); ok {
+		return rf(ctx, teamName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domains.PendingReview); ok {
+		r0 = rf(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domains.PendingReview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPendingReviews provides a mock function with given fields: ctx
+func (_m *PRRepository) GetPendingReviews(ctx context.Context) ([]domains.PendingReview, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingReviews")
+	}
+
+	var r0 []domains.PendingReview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domains.PendingReview, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domains.PendingReview); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domains.PendingReview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTimedOutReviewers provides a mock function with given fields: ctx, prID
+func (_m *PRRepository) GetTimedOutReviewers(ctx context.Context, prID string) ([]string, error) {
+	ret := _m.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTimedOutReviewers")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, prID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, prID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkOverdue provides a mock function with given fields: ctx, reviews, at
+func (_m *PRRepository) MarkOverdue(ctx context.Context, reviews []domains.PendingReview, at time.Time) error {
+	ret := _m.Called(ctx, reviews, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkOverdue")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domains.PendingReview, time.Time) error); ok {
+		r0 = rf(ctx, reviews, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MergeWithOverride provides a mock function with given fields: ctx, pr, override
+func (_m *PRRepository) MergeWithOverride(ctx context.Context, pr *domains.PullRequest, override *domains.MergeOverride) error {
+	ret := _m.Called(ctx, pr, override)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeWithOverride")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.PullRequest, *domains.MergeOverride) error); ok {
+		r0 = rf(ctx, pr, override)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetReviewState provides a mock function with given fields: ctx, prID, reviewerID, state
+func (_m *PRRepository) SetReviewState(ctx context.Context, prID string, reviewerID string, state domains.ReviewState) error {
+	ret := _m.Called(ctx, prID, reviewerID, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetReviewState")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, domains.ReviewState) error); ok {
+		r0 = rf(ctx, prID, reviewerID, state)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, pr
 func (_m *PRRepository) Update(ctx context.Context, pr *domains.PullRequest) error {
 	ret := _m.Called(ctx, pr)
@@ -7,6 +7,8 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // PRService is an autogenerated mock type for the PRService type
@@ -14,6 +16,66 @@ type PRService struct {
 	mock.Mock
 }
 
+// AddReviewer provides a mock function with given fields: ctx, prID, reviewerID
+func (_m *PRService) AddReviewer(ctx context.Context, prID string, reviewerID string) (*domains.PullRequest, error) {
+	ret := _m.Called(ctx, prID, reviewerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddReviewer")
+	}
+
+	var r0 *domains.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domains.PullRequest, error)); ok {
+		return rf(ctx, prID, reviewerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domains.PullRequest); ok {
+		r0 = rf(ctx, prID, reviewerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, prID, reviewerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClosePR provides a mock function with given fields: ctx, prID
+func (_m *PRService) ClosePR(ctx context.Context, prID string) (*domains.PullRequest, error) {
+	ret := _m.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClosePR")
+	}
+
+	var r0 *domains.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domains.PullRequest, error)); ok {
+		return rf(ctx, prID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domains.PullRequest); ok {
+		r0 = rf(ctx, prID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreatePR provides a mock function with given fields: ctx, input
 func (_m *PRService) CreatePR(ctx context.Context, input domains.PullRequestInput) (*domains.PullRequest, error) {
 	ret := _m.Called(ctx, input)
@@ -44,6 +106,242 @@ func (_m *PRService) CreatePR(ctx context.Context, input domains.PullRequestInpu
 	return r0, r1
 }
 
+// DeactivateTeam provides a mock function with given fields: ctx, teamName
+func (_m *PRService) DeactivateTeam(ctx context.Context, teamName string) (*domains.TeamDeactivation, error) {
+	ret := _m.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeactivateTeam")
+	}
+
+	var r0 *domains.TeamDeactivation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domains.TeamDeactivation, error)); ok {
+		return rf(ctx, teamName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domains.TeamDeactivation); ok {
+		r0 = rf(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.TeamDeactivation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeactivateUser provides a mock function with given fields: ctx, userID
+func (_m *PRService) DeactivateUser(ctx context.Context, userID string) (*domains.ReassignmentReport, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeactivateUser")
+	}
+
+	var r0 *domains.ReassignmentReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domains.ReassignmentReport, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domains.ReassignmentReport); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.ReassignmentReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EscalateStaleReviews provides a mock function with given fields: ctx, now
+func (_m *PRService) EscalateStaleReviews(ctx context.Context, now time.Time) (int, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EscalateStaleReviews")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ForceMergePR provides a mock function with given fields: ctx, prID, override
+func (_m *PRService) ForceMergePR(ctx context.Context, prID string, override *domains.MergeOverride) (*domains.PullRequest, error) {
+	ret := _m.Called(ctx, prID, override)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForceMergePR")
+	}
+
+	var r0 *domains.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domains.MergeOverride) (*domains.PullRequest, error)); ok {
+		return rf(ctx, prID, override)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domains.MergeOverride) *domains.PullRequest); ok {
+		r0 = rf(ctx, prID, override)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *domains.MergeOverride) error); ok {
+		r1 = rf(ctx, prID, override)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHistory provides a mock function with given fields: ctx, prID
+func (_m *PRService) GetHistory(ctx context.Context, prID string) ([]domains.PREvent, error) {
+	ret := _m.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHistory")
+	}
+
+	var r0 []domains.PREvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domains.PREvent, error)); ok {
+		return rf(ctx, prID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domains.PREvent); ok {
+		r0 = rf(ctx, prID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domains.PREvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOverdueReviews provides a mock function with given fields: ctx, teamName
+func (_m *PRService) GetOverdueReviews(ctx context.Context, teamName string) ([]domains.PendingReview, error) {
+	ret := _m.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOverdueReviews")
+	}
+
+	var r0 []domains.PendingReview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domains.PendingReview, error)); ok {
+		return rf(ctx, teamName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domains.PendingReview); ok {
+		r0 = rf(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domains.PendingReview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkOverdueReviews provides a mock function with given fields: ctx, now
+func (_m *PRService) MarkOverdueReviews(ctx context.Context, now time.Time) (int, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkOverdueReviews")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkReady provides a mock function with given fields: ctx, prID, changedFiles
+func (_m *PRService) MarkReady(ctx context.Context, prID string, changedFiles []string) (*domains.PullRequest, error) {
+	ret := _m.Called(ctx, prID, changedFiles)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkReady")
+	}
+
+	var r0 *domains.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (*domains.PullRequest, error)); ok {
+		return rf(ctx, prID, changedFiles)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) *domains.PullRequest); ok {
+		r0 = rf(ctx, prID, changedFiles)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, prID, changedFiles)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // MergePR provides a mock function with given fields: ctx, prID
 func (_m *PRService) MergePR(ctx context.Context, prID string) (*domains.PullRequest, error) {
 	ret := _m.Called(ctx, prID)
@@ -74,9 +372,99 @@ func (_m *PRService) MergePR(ctx context.Context, prID string) (*domains.PullReq
 	return r0, r1
 }
 
-// UpdateReviewer provides a mock function with given fields: ctx, prID, oldReviewerID
-func (_m *PRService) UpdateReviewer(ctx context.Context, prID string, oldReviewerID string) (*domains.PullRequest, string, error) {
-	ret := _m.Called(ctx, prID, oldReviewerID)
+// RemoveReviewer provides a mock function with given fields: ctx, prID, reviewerID
+func (_m *PRService) RemoveReviewer(ctx context.Context, prID string, reviewerID string) (*domains.PullRequest, error) {
+	ret := _m.Called(ctx, prID, reviewerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveReviewer")
+	}
+
+	var r0 *domains.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domains.PullRequest, error)); ok {
+		return rf(ctx, prID, reviewerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domains.PullRequest); ok {
+		r0 = rf(ctx, prID, reviewerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, prID, reviewerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReopenPR provides a mock function with given fields: ctx, prID
+func (_m *PRService) ReopenPR(ctx context.Context, prID string) (*domains.PullRequest, error) {
+	ret := _m.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReopenPR")
+	}
+
+	var r0 *domains.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domains.PullRequest, error)); ok {
+		return rf(ctx, prID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domains.PullRequest); ok {
+		r0 = rf(ctx, prID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubmitReview provides a mock function with given fields: ctx, prID, reviewerID, state
+func (_m *PRService) SubmitReview(ctx context.Context, prID string, reviewerID string, state domains.ReviewState) (*domains.PullRequest, error) {
+	ret := _m.Called(ctx, prID, reviewerID, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubmitReview")
+	}
+
+	var r0 *domains.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, domains.ReviewState) (*domains.PullRequest, error)); ok {
+		return rf(ctx, prID, reviewerID, state)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, domains.ReviewState) *domains.PullRequest); ok {
+		r0 = rf(ctx, prID, reviewerID, state)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, domains.ReviewState) error); ok {
+		r1 = rf(ctx, prID, reviewerID, state)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateReviewer provides a mock function with given fields: ctx, prID, oldReviewerID, newReviewerID
+func (_m *PRService) UpdateReviewer(ctx context.Context, prID string, oldReviewerID string, newReviewerID string) (*domains.PullRequest, string, error) {
+	ret := _m.Called(ctx, prID, oldReviewerID, newReviewerID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateReviewer")
@@ -85,25 +473,25 @@ func (_m *PRService) UpdateReviewer(ctx context.Context, prID string, oldReviewe
 	var r0 *domains.PullRequest
 	var r1 string
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domains.PullRequest, string, error)); ok {
-		return rf(ctx, prID, oldReviewerID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*domains.PullRequest, string, error)); ok {
+		return rf(ctx, prID, oldReviewerID, newReviewerID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domains.PullRequest); ok {
-		r0 = rf(ctx, prID, oldReviewerID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *domains.PullRequest); ok {
+		r0 = rf(ctx, prID, oldReviewerID, newReviewerID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domains.PullRequest)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) string); ok {
-		r1 = rf(ctx, prID, oldReviewerID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) string); ok {
+		r1 = rf(ctx, prID, oldReviewerID, newReviewerID)
 	} else {
 		r1 = ret.Get(1).(string)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
-		r2 = rf(ctx, prID, oldReviewerID)
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, string) error); ok {
+		r2 = rf(ctx, prID, oldReviewerID, newReviewerID)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -0,0 +1,48 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	domains "ReviewerAssignmentService/internal/domains"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ReviewerSelector is an autogenerated mock type for the ReviewerSelector type
+type ReviewerSelector struct {
+	mock.Mock
+}
+
+// Select provides a mock function with given fields: teamName, candidates, limit
+func (_m *ReviewerSelector) Select(teamName string, candidates []domains.ReviewCandidate, limit int) []string {
+	ret := _m.Called(teamName, candidates, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Select")
+	}
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string, []domains.ReviewCandidate, int) []string); ok {
+		r0 = rf(teamName, candidates, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// NewReviewerSelector creates a new instance of ReviewerSelector. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReviewerSelector(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReviewerSelector {
+	mock := &ReviewerSelector{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -90,6 +90,36 @@ func (_m *TeamRepository) GetByName(ctx context.Context, teamName string) (*doma
 	return r0, r1
 }
 
+// GetSettings provides a mock function with given fields: ctx, teamName
+func (_m *TeamRepository) GetSettings(ctx context.Context, teamName string) (*domains.TeamSettings, error) {
+	ret := _m.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSettings")
+	}
+
+	var r0 *domains.TeamSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domains.TeamSettings, error)); ok {
+		return rf(ctx, teamName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domains.TeamSettings); ok {
+		r0 = rf(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.TeamSettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewTeamRepository creates a new instance of TeamRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTeamRepository(t interface {
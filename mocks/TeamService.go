@@ -44,6 +44,36 @@ func (_m *TeamService) CreateTeam(ctx context.Context, team *domains.Team) (*dom
 	return r0, r1
 }
 
+// GetOwnershipRules provides a mock function with given fields: ctx, teamName
+func (_m *TeamService) GetOwnershipRules(ctx context.Context, teamName string) ([]domains.OwnershipRule, error) {
+	ret := _m.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOwnershipRules")
+	}
+
+	var r0 []domains.OwnershipRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domains.OwnershipRule, error)); ok {
+		return rf(ctx, teamName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domains.OwnershipRule); ok {
+		r0 = rf(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domains.OwnershipRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTeam provides a mock function with given fields: ctx, name
 func (_m *TeamService) GetTeam(ctx context.Context, name string) (*domains.Team, error) {
 	ret := _m.Called(ctx, name)
@@ -74,6 +104,36 @@ func (_m *TeamService) GetTeam(ctx context.Context, name string) (*domains.Team,
 	return r0, r1
 }
 
+// SetOwnershipRules provides a mock function with given fields: ctx, teamName, content
+func (_m *TeamService) SetOwnershipRules(ctx context.Context, teamName string, content string) ([]domains.OwnershipRule, error) {
+	ret := _m.Called(ctx, teamName, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetOwnershipRules")
+	}
+
+	var r0 []domains.OwnershipRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]domains.OwnershipRule, error)); ok {
+		return rf(ctx, teamName, content)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []domains.OwnershipRule); ok {
+		r0 = rf(ctx, teamName, content)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domains.OwnershipRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, teamName, content)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewTeamService creates a new instance of TeamService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTeamService(t interface {
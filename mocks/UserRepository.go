@@ -14,6 +14,24 @@ type UserRepository struct {
 	mock.Mock
 }
 
+// AddUnavailability provides a mock function with given fields: ctx, period
+func (_m *UserRepository) AddUnavailability(ctx context.Context, period *domains.Unavailability) error {
+	ret := _m.Called(ctx, period)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddUnavailability")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.Unavailability) error); ok {
+		r0 = rf(ctx, period)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Count provides a mock function with given fields: ctx
 func (_m *UserRepository) Count(ctx context.Context) (int, error) {
 	ret := _m.Called(ctx)
@@ -60,17 +78,35 @@ func (_m *UserRepository) Create(ctx context.Context, user *domains.User) error
 	return r0
 }
 
-// DeactivateTeamMembers provides a mock function with given fields: ctx, teamName
-func (_m *UserRepository) DeactivateTeamMembers(ctx context.Context, teamName string) error {
-	ret := _m.Called(ctx, teamName)
+// DeactivateTeamMembers provides a mock function with given fields: ctx, teamName, reassigned
+func (_m *UserRepository) DeactivateTeamMembers(ctx context.Context, teamName string, reassigned []*domains.PullRequest) error {
+	ret := _m.Called(ctx, teamName, reassigned)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeactivateTeamMembers")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, teamName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, []*domains.PullRequest) error); ok {
+		r0 = rf(ctx, teamName, reassigned)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeactivateUsers provides a mock function with given fields: ctx, userIDs, reassigned
+func (_m *UserRepository) DeactivateUsers(ctx context.Context, userIDs []string, reassigned []*domains.PullRequest) error {
+	ret := _m.Called(ctx, userIDs, reassigned)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeactivateUsers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, []*domains.PullRequest) error); ok {
+		r0 = rf(ctx, userIDs, reassigned)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -78,6 +114,34 @@ func (_m *UserRepository) DeactivateTeamMembers(ctx context.Context, teamName st
 	return r0
 }
 
+// DeleteUnavailability provides a mock function with given fields: ctx, id
+func (_m *UserRepository) DeleteUnavailability(ctx context.Context, id int64) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUnavailability")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Exists provides a mock function with given fields: ctx, userName
 func (_m *UserRepository) Exists(ctx context.Context, userName string) (bool, error) {
 	ret := _m.Called(ctx, userName)
@@ -106,6 +170,66 @@ func (_m *UserRepository) Exists(ctx context.Context, userName string) (bool, er
 	return r0, r1
 }
 
+// GetActiveCandidatesByIDs provides a mock function with given fields: ctx, userIDs, excludeUserIDs
+func (_m *UserRepository) GetActiveCandidatesByIDs(ctx context.Context, userIDs []string, excludeUserIDs []string) ([]domains.ReviewCandidate, error) {
+	ret := _m.Called(ctx, userIDs, excludeUserIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveCandidatesByIDs")
+	}
+
+	var r0 []domains.ReviewCandidate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, []string) ([]domains.ReviewCandidate, error)); ok {
+		return rf(ctx, userIDs, excludeUserIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, []string) []domains.ReviewCandidate); ok {
+		r0 = rf(ctx, userIDs, excludeUserIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domains.ReviewCandidate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, []string) error); ok {
+		r1 = rf(ctx, userIDs, excludeUserIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetActiveCandidatesByTeam provides a mock function with given fields: ctx, teamName, excludeUserIDs
+func (_m *UserRepository) GetActiveCandidatesByTeam(ctx context.Context, teamName string, excludeUserIDs []string) ([]domains.ReviewCandidate, error) {
+	ret := _m.Called(ctx, teamName, excludeUserIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveCandidatesByTeam")
+	}
+
+	var r0 []domains.ReviewCandidate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) ([]domains.ReviewCandidate, error)); ok {
+		return rf(ctx, teamName, excludeUserIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) []domains.ReviewCandidate); ok {
+		r0 = rf(ctx, teamName, excludeUserIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domains.ReviewCandidate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, teamName, excludeUserIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByID provides a mock function with given fields: ctx, id
 func (_m *UserRepository) GetByID(ctx context.Context, id string) (*domains.User, error) {
 	ret := _m.Called(ctx, id)
@@ -136,29 +260,29 @@ func (_m *UserRepository) GetByID(ctx context.Context, id string) (*domains.User
 	return r0, r1
 }
 
-// GetRandomActiveUsersByTeam provides a mock function with given fields: ctx, teamName, excludeUserID, limit
-func (_m *UserRepository) GetRandomActiveUsersByTeam(ctx context.Context, teamName string, excludeUserID string, limit int) ([]string, error) {
-	ret := _m.Called(ctx, teamName, excludeUserID, limit)
+// GetUnavailability provides a mock function with given fields: ctx, userID
+func (_m *UserRepository) GetUnavailability(ctx context.Context, userID string) ([]domains.Unavailability, error) {
+	ret := _m.Called(ctx, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetRandomActiveUsersByTeam")
+		panic("no return value specified for GetUnavailability")
 	}
 
-	var r0 []string
+	var r0 []domains.Unavailability
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) ([]string, error)); ok {
-		return rf(ctx, teamName, excludeUserID, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domains.Unavailability, error)); ok {
+		return rf(ctx, userID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) []string); ok {
-		r0 = rf(ctx, teamName, excludeUserID, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domains.Unavailability); ok {
+		r0 = rf(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
+			r0 = ret.Get(0).([]domains.Unavailability)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, int) error); ok {
-		r1 = rf(ctx, teamName, excludeUserID, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -14,6 +14,54 @@ type UserService struct {
 	mock.Mock
 }
 
+// AddUnavailability provides a mock function with given fields: ctx, period
+func (_m *UserService) AddUnavailability(ctx context.Context, period *domains.Unavailability) (*domains.Unavailability, error) {
+	ret := _m.Called(ctx, period)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddUnavailability")
+	}
+
+	var r0 *domains.Unavailability
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.Unavailability) (*domains.Unavailability, error)); ok {
+		return rf(ctx, period)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.Unavailability) *domains.Unavailability); ok {
+		r0 = rf(ctx, period)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domains.Unavailability)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domains.Unavailability) error); ok {
+		r1 = rf(ctx, period)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteUnavailability provides a mock function with given fields: ctx, id
+func (_m *UserService) DeleteUnavailability(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUnavailability")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetGlobalStats provides a mock function with given fields: ctx
 func (_m *UserService) GetGlobalStats(ctx context.Context) (*domains.GlobalStats, error) {
 	ret := _m.Called(ctx)
@@ -44,6 +92,36 @@ func (_m *UserService) GetGlobalStats(ctx context.Context) (*domains.GlobalStats
 	return r0, r1
 }
 
+// GetUnavailability provides a mock function with given fields: ctx, userID
+func (_m *UserService) GetUnavailability(ctx context.Context, userID string) ([]domains.Unavailability, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUnavailability")
+	}
+
+	var r0 []domains.Unavailability
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domains.Unavailability, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domains.Unavailability); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domains.Unavailability)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserPRs provides a mock function with given fields: ctx, userID
 func (_m *UserService) GetUserPRs(ctx context.Context, userID string) ([]*domains.PullRequestShort, error) {
 	ret := _m.Called(ctx, userID)
@@ -0,0 +1,233 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	domains "ReviewerAssignmentService/internal/domains"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// WebhookRepository is an autogenerated mock type for the WebhookRepository type
+type WebhookRepository struct {
+	mock.Mock
+}
+
+// CreateSubscription provides a mock function with given fields: ctx, sub
+func (_m *WebhookRepository) CreateSubscription(ctx context.Context, sub *domains.WebhookSubscription) error {
+	ret := _m.Called(ctx, sub)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSubscription")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.WebhookSubscription) error); ok {
+		r0 = rf(ctx, sub)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteSubscription provides a mock function with given fields: ctx, id
+func (_m *WebhookRepository) DeleteSubscription(ctx context.Context, id int64) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSubscription")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeliveries provides a mock function with given fields: ctx, subscriptionID, limit
+func (_m *WebhookRepository) GetDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]domains.WebhookDelivery, error) {
+	ret := _m.Called(ctx, subscriptionID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeliveries")
+	}
+
+	var r0 []domains.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) ([]domains.WebhookDelivery, error)); ok {
+		return rf(ctx, subscriptionID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) []domains.WebhookDelivery); ok {
+		r0 = rf(ctx, subscriptionID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domains.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, subscriptionID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDueDeliveries provides a mock function with given fields: ctx, subscriptionID, now, limit
+func (_m *WebhookRepository) GetDueDeliveries(ctx context.Context, subscriptionID int64, now time.Time, limit int) ([]domains.PendingDelivery, error) {
+	ret := _m.Called(ctx, subscriptionID, now, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDueDeliveries")
+	}
+
+	var r0 []domains.PendingDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, int) ([]domains.PendingDelivery, error)); ok {
+		return rf(ctx, subscriptionID, now, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, int) []domains.PendingDelivery); ok {
+		r0 = rf(ctx, subscriptionID, now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domains.PendingDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time, int) error); ok {
+		r1 = rf(ctx, subscriptionID, now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEventsAfter provides a mock function with given fields: ctx, afterID, types, limit
+func (_m *WebhookRepository) GetEventsAfter(ctx context.Context, afterID int64, types []domains.PREventType, limit int) ([]domains.PREvent, error) {
+	ret := _m.Called(ctx, afterID, types, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventsAfter")
+	}
+
+	var r0 []domains.PREvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []domains.PREventType, int) ([]domains.PREvent, error)); ok {
+		return rf(ctx, afterID, types, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []domains.PREventType, int) []domains.PREvent); ok {
+		r0 = rf(ctx, afterID, types, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domains.PREvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, []domains.PREventType, int) error); ok {
+		r1 = rf(ctx, afterID, types, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSubscriptions provides a mock function with given fields: ctx
+func (_m *WebhookRepository) GetSubscriptions(ctx context.Context) ([]domains.WebhookSubscription, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscriptions")
+	}
+
+	var r0 []domains.WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domains.WebhookSubscription, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domains.WebhookSubscription); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domains.WebhookSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QueueDeliveries provides a mock function with given fields: ctx, subscriptionID, events, at
+func (_m *WebhookRepository) QueueDeliveries(ctx context.Context, subscriptionID int64, events []domains.PREvent, at time.Time) error {
+	ret := _m.Called(ctx, subscriptionID, events, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueueDeliveries")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []domains.PREvent, time.Time) error); ok {
+		r0 = rf(ctx, subscriptionID, events, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RecordDelivery provides a mock function with given fields: ctx, delivery
+func (_m *WebhookRepository) RecordDelivery(ctx context.Context, delivery *domains.WebhookDelivery) error {
+	ret := _m.Called(ctx, delivery)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domains.WebhookDelivery) error); ok {
+		r0 = rf(ctx, delivery)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewWebhookRepository creates a new instance of WebhookRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookRepository {
+	mock := &WebhookRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
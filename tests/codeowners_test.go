@@ -0,0 +1,67 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ReviewerAssignmentService/internal/codeowners"
+	"ReviewerAssignmentService/internal/domains"
+)
+
+func TestCodeowners_Parse(t *testing.T) {
+	content := `
+# default owners
+*            lead
+
+/billing/    owner @Payments   # inline comment
+docs/*.md
+`
+
+	rules, err := codeowners.Parse(content)
+	require.NoError(t, err)
+	assert.Equal(t, []domains.OwnershipRule{
+		{Pattern: "*", Owners: []string{"lead"}},
+		{Pattern: "/billing/", Owners: []string{"owner", "@Payments"}},
+		{Pattern: "docs/*.md", Owners: []string{}},
+	}, rules)
+
+	_, err = codeowners.Parse("!vendor/ lead")
+	assert.ErrorIs(t, err, codeowners.ErrInvalidRule)
+}
+
+func TestCodeowners_Match(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		path    string
+		match   bool
+	}{
+		{pattern: "*.go", path: "internal/service/pr_service.go", match: true},
+		{pattern: "*.go", path: "README.md", match: false},
+		{pattern: "/billing/", path: "billing/invoice/pdf.go", match: true},
+		{pattern: "/billing/", path: "src/billing/pdf.go", match: false},
+		{pattern: "billing/", path: "src/billing/pdf.go", match: true},
+		{pattern: "docs/*", path: "docs/intro.md", match: true},
+		{pattern: "docs/*", path: "docs/guides/intro.md", match: false},
+		{pattern: "docs/**/*.md", path: "docs/guides/intro.md", match: true},
+		{pattern: "**/migrations", path: "internal/database/migrations/001_init.sql", match: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.pattern+" "+tc.path, func(t *testing.T) {
+			assert.Equal(t, tc.match, codeowners.Match(tc.pattern, tc.path))
+		})
+	}
+}
+
+func TestCodeowners_OwnersLastMatchWins(t *testing.T) {
+	rules := []domains.OwnershipRule{
+		{Pattern: "*", Owners: []string{"lead"}},
+		{Pattern: "*.sql", Owners: []string{"dba"}},
+	}
+
+	ruleset := codeowners.NewRuleset(rules)
+	assert.Equal(t, []string{"dba"}, ruleset.Owners("/internal/database/migrations/001_init.sql"))
+	assert.Equal(t, []string{"lead"}, ruleset.Owners("cmd/main.go"))
+}
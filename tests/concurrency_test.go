@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"ReviewerAssignmentService/internal/domains"
+	"ReviewerAssignmentService/internal/repository"
+	"ReviewerAssignmentService/internal/service"
+	"ReviewerAssignmentService/mocks"
+)
+
+func TestPRService_ConcurrentReassign(t *testing.T) {
+	runContract(t, func(t *testing.T, repos contractRepos) {
+		ctx := context.Background()
+		seedTeam(t, repos, &domains.Team{Name: "Team"}, "author", "r1", "r2", "r3", "r4")
+		initial := seedPR(t, repos, "pr1", "author", "r1", "r2")
+		svc := service.NewPRService(repos.PR, repos.User, repos.Team, repos.Ownership, repos.UnitOfWork,
+			service.AssignmentConfig{})
+
+		const requests = 8
+		errs := make([]error, requests)
+		start := make(chan struct{})
+		var wg sync.WaitGroup
+		for i := range requests {
+			oldID := []string{"r1", "r2"}[i%2]
+			wg.Go(func() {
+				<-start
+				_, _, errs[i] = svc.UpdateReviewer(ctx, "pr1", oldID, "")
+			})
+		}
+		close(start)
+		wg.Wait()
+
+		succeeded := 0
+		for _, err := range errs {
+			switch {
+			case err == nil:
+				succeeded++
+			case errors.Is(err, service.ErrConcurrentModification),
+				errors.Is(err, service.ErrReviewerNotAssigned),
+				errors.Is(err, service.ErrNoCandidates):
+			default:
+				t.Errorf("unexpected error: %v", err)
+			}
+		}
+		assert.Positive(t, succeeded)
+
+		final, err := repos.PR.GetByID(ctx, "pr1")
+		require.NoError(t, err)
+		assert.Equal(t, initial.Version+succeeded, final.Version, "every successful reassignment bumps the version once")
+		require.Len(t, final.AssignedReviewers, 2)
+		assert.NotEqual(t, final.AssignedReviewers[0], final.AssignedReviewers[1])
+		assert.NotContains(t, final.AssignedReviewers, "author")
+
+		events, err := repos.PR.GetEvents(ctx, "pr1")
+		require.NoError(t, err)
+		replaced := slices.DeleteFunc(eventTypesOf(events), func(eventType domains.PREventType) bool {
+			return eventType != domains.PREventReviewerReplaced
+		})
+		assert.Len(t, replaced, succeeded, "only successful reassignments are recorded")
+	})
+}
+
+func TestPRService_StaleMergeConflicts(t *testing.T) {
+	prRepo := mocks.NewPRRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	teamRepo := mocks.NewTeamRepository(t)
+
+	prRepo.On("GetByID", mock.Anything, "pr1").Return(&domains.PullRequest{
+		ID:       "pr1",
+		AuthorID: "author",
+		Status:   domains.PRStatusOpen,
+	}, nil)
+	userRepo.On("GetByID", mock.Anything, "author").Return(&domains.User{TeamName: "Team"}, nil)
+	teamRepo.On("GetSettings", mock.Anything, "Team").Return(&domains.TeamSettings{}, nil)
+	prRepo.On("Update", mock.Anything, mock.Anything).Return(repository.ErrConflict)
+
+	svc := newPRService(t, prRepo, userRepo, teamRepo, mocks.NewOwnershipRepository(t), service.AssignmentConfig{})
+	_, err := svc.MergePR(context.Background(), "pr1")
+
+	assert.ErrorIs(t, err, service.ErrConcurrentModification)
+}
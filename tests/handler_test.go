@@ -2,6 +2,7 @@ package tests
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -12,6 +13,7 @@ import (
 
 	"ReviewerAssignmentService/internal/domains"
 	"ReviewerAssignmentService/internal/handler"
+	"ReviewerAssignmentService/internal/service"
 	"ReviewerAssignmentService/mocks"
 )
 
@@ -59,7 +61,7 @@ func TestHandler_CreatePR(t *testing.T) {
 			prService := mocks.NewPRService(t)
 			tt.mock(prService)
 
-			h := handler.New(mocks.NewTeamService(t), mocks.NewUserService(t), prService)
+			h := handler.New(mocks.NewTeamService(t), mocks.NewUserService(t), prService, mocks.NewWebhookService(t), mocks.NewIntegrationService(t), mocks.NewIdempotencyService(t))
 			router := h.InitRoutes()
 
 			var body []byte
@@ -72,3 +74,137 @@ func TestHandler_CreatePR(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_PRHistoryActor(t *testing.T) {
+	prService := mocks.NewPRService(t)
+	prService.On("GetHistory", mock.MatchedBy(func(ctx context.Context) bool {
+		return domains.ActorFromContext(ctx) == "admin"
+	}), "pr-1").Return([]domains.PREvent{{ID: 1, PullRequestID: "pr-1", Type: domains.PREventCreated}}, nil)
+
+	h := handler.New(mocks.NewTeamService(t), mocks.NewUserService(t), prService, mocks.NewWebhookService(t), mocks.NewIntegrationService(t), mocks.NewIdempotencyService(t))
+	router := h.InitRoutes()
+
+	req := httptest.NewRequest("GET", "/pullRequest/history?pull_request_id=pr-1", nil)
+	req.Header.Set(handler.ActorHeader, "admin")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+}
+
+func TestHandler_OwnershipRulesTooLarge(t *testing.T) {
+	// The team service has no expectations: an oversized file must not be stored, not even in part.
+	h := handler.New(mocks.NewTeamService(t), mocks.NewUserService(t), mocks.NewPRService(t), mocks.NewWebhookService(t), mocks.NewIntegrationService(t), mocks.NewIdempotencyService(t))
+	router := h.InitRoutes()
+
+	body := bytes.Repeat([]byte("docs/ lead\n"), (1<<20)/10)
+	req := httptest.NewRequest("POST", "/team/codeowners?team_name=Team", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413, got %d", rec.Code)
+	}
+}
+
+func TestHandler_CreateTeamRequiredReviewers(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		expectStatus   int
+		expectRequired int
+	}{
+		{name: "OK: Omitted takes the default", body: `{"team_name":"Team","members":[]}`, expectStatus: http.StatusCreated},
+		{name: "OK: Explicit count", body: `{"team_name":"Team","members":[],"required_reviewers":3}`,
+			expectStatus: http.StatusCreated, expectRequired: 3},
+		{name: "Error: Zero", body: `{"team_name":"Team","members":[],"required_reviewers":0}`,
+			expectStatus: http.StatusBadRequest},
+		{name: "Error: Negative", body: `{"team_name":"Team","members":[],"required_reviewers":-1}`,
+			expectStatus: http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			teamService := mocks.NewTeamService(t)
+			if tc.expectStatus == http.StatusCreated {
+				teamService.On("CreateTeam", mock.Anything, mock.MatchedBy(func(team *domains.Team) bool {
+					return team.RequiredReviewers == tc.expectRequired
+				})).Return(&domains.Team{Name: "Team"}, nil)
+			}
+			h := handler.New(teamService, mocks.NewUserService(t), mocks.NewPRService(t), mocks.NewWebhookService(t), mocks.NewIntegrationService(t), mocks.NewIdempotencyService(t))
+			router := h.InitRoutes()
+
+			req := httptest.NewRequest("POST", "/team/add", bytes.NewBufferString(tc.body))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectStatus {
+				t.Fatalf("expected %d, got %d: %s", tc.expectStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_ForceMergeForbidden(t *testing.T) {
+	prService := mocks.NewPRService(t)
+	prService.On("ForceMergePR", mock.Anything, "pr-1", mock.Anything).Return(nil, service.ErrOverrideForbidden)
+
+	h := handler.New(mocks.NewTeamService(t), mocks.NewUserService(t), prService, mocks.NewWebhookService(t), mocks.NewIntegrationService(t), mocks.NewIdempotencyService(t))
+	router := h.InitRoutes()
+
+	body := []byte(`{"pull_request_id":"pr-1","force":true,"forced_by":"dev"}`)
+	req := httptest.NewRequest("POST", "/pullRequest/merge", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", rec.Code)
+	}
+}
+
+func TestHandler_MarkReadyMergedPR(t *testing.T) {
+	prService := mocks.NewPRService(t)
+	prService.On("MarkReady", mock.Anything, "pr-1", []string(nil)).Return(nil, service.ErrPRMerged)
+
+	h := handler.New(mocks.NewTeamService(t), mocks.NewUserService(t), prService, mocks.NewWebhookService(t), mocks.NewIntegrationService(t), mocks.NewIdempotencyService(t))
+	router := h.InitRoutes()
+
+	req := httptest.NewRequest("POST", "/pullRequest/ready", bytes.NewReader([]byte(`{"pull_request_id":"pr-1"}`)))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	var resp struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if rec.Code != http.StatusConflict || resp.Error.Code != handler.ErrCodePRMerged ||
+		resp.Error.Message != handler.ErrMsgReadyMergedPR {
+		t.Fatalf("expected 409 %s %q, got %d %+v", handler.ErrCodePRMerged, handler.ErrMsgReadyMergedPR, rec.Code, resp.Error)
+	}
+}
+
+func TestHandler_OverdueReviewsTeamFilter(t *testing.T) {
+	prService := mocks.NewPRService(t)
+	prService.On("GetOverdueReviews", mock.Anything, "ghost").Return(nil, service.ErrTeamNotFound)
+
+	h := handler.New(mocks.NewTeamService(t), mocks.NewUserService(t), prService, mocks.NewWebhookService(t), mocks.NewIntegrationService(t), mocks.NewIdempotencyService(t))
+	router := h.InitRoutes()
+
+	for target, status := range map[string]int{
+		"/pullRequest/overdue?team_name=":      http.StatusBadRequest,
+		"/pullRequest/overdue?team_name=ghost": http.StatusNotFound,
+	} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest("GET", target, nil))
+		if rec.Code != status {
+			t.Fatalf("%s: expected %d, got %d", target, status, rec.Code)
+		}
+	}
+}
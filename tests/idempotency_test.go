@@ -0,0 +1,181 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"ReviewerAssignmentService/internal/domains"
+	"ReviewerAssignmentService/internal/handler"
+	"ReviewerAssignmentService/internal/service"
+	"ReviewerAssignmentService/mocks"
+)
+
+func TestIdempotencyService_Begin(t *testing.T) {
+	testCases := []struct {
+		name        string
+		reserved    bool
+		stored      *domains.IdempotentResponse
+		expectReply bool
+		expectError error
+	}{
+		{name: "OK: New key", reserved: true},
+		{
+			name:        "OK: Completed request is replayed",
+			stored:      &domains.IdempotentResponse{RequestHash: "h1", StatusCode: 201, Body: []byte(`{}`), Completed: true},
+			expectReply: true,
+		},
+		{
+			name:        "Error: Different request",
+			stored:      &domains.IdempotentResponse{RequestHash: "other", Completed: true},
+			expectError: service.ErrIdempotencyKeyReused,
+		},
+		{
+			name:        "Error: Still in progress",
+			stored:      &domains.IdempotentResponse{RequestHash: "h1"},
+			expectError: service.ErrIdempotencyInProgress,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewIdempotencyRepository(t)
+			repo.On("Reserve", mock.Anything, mock.MatchedBy(func(r *domains.IdempotentResponse) bool {
+				return r.Key == "k1" && r.Endpoint == "/pullRequest/create" && r.ExpiresAt.Before(time.Now().Add(time.Hour/2))
+			})).Return(tc.reserved, nil)
+			if !tc.reserved {
+				repo.On("Get", mock.Anything, "k1", "/pullRequest/create").Return(tc.stored, nil)
+			}
+
+			svc := service.NewIdempotencyService(repo, time.Hour)
+			stored, err := svc.Begin(context.Background(), "k1", "/pullRequest/create", "h1")
+
+			if tc.expectError != nil {
+				assert.ErrorIs(t, err, tc.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectReply, stored != nil)
+		})
+	}
+}
+
+func TestIdempotencyService_ReservationLease(t *testing.T) {
+	runContract(t, func(t *testing.T, repos contractRepos) {
+		ctx := context.Background()
+		svc := service.NewIdempotencyService(repos.Idempotency, time.Hour)
+
+		stored, err := svc.Begin(ctx, "k1", "/pullRequest/create", "h1")
+		require.NoError(t, err)
+		require.Nil(t, stored)
+
+		reserved, err := repos.Idempotency.Get(ctx, "k1", "/pullRequest/create")
+		require.NoError(t, err)
+		require.NotNil(t, reserved)
+		assert.False(t, reserved.Completed)
+		assert.True(t, reserved.ExpiresAt.Before(time.Now().Add(time.Hour/2)),
+			"an unfinished request must not hold the key for the whole window")
+
+		require.NoError(t, svc.Complete(ctx, "k1", "/pullRequest/create", http.StatusCreated, []byte(`{}`)))
+
+		completed, err := repos.Idempotency.Get(ctx, "k1", "/pullRequest/create")
+		require.NoError(t, err)
+		require.NotNil(t, completed)
+		assert.True(t, completed.Completed)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), completed.ExpiresAt, time.Minute)
+	})
+}
+
+func TestHandler_IdempotentCreate(t *testing.T) {
+	body := []byte(`{"pull_request_id":"pr-1","pull_request_name":"Test","author_id":"u1"}`)
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", bytes.NewReader(body))
+		req.Header.Set(handler.IdempotencyKeyHeader, "retry-1")
+		return req
+	}
+
+	t.Run("First request is stored", func(t *testing.T) {
+		prService := mocks.NewPRService(t)
+		idempotency := mocks.NewIdempotencyService(t)
+		idempotency.On("Begin", mock.Anything, "retry-1", "/pullRequest/create", mock.Anything).Return(nil, nil)
+		prService.On("CreatePR", mock.Anything, mock.Anything).Return(&domains.PullRequest{ID: "pr-1"}, nil).Once()
+		idempotency.On("Complete", mock.Anything, "retry-1", "/pullRequest/create", http.StatusCreated,
+			mock.MatchedBy(func(stored []byte) bool { return bytes.Contains(stored, []byte(`"pr-1"`)) })).Return(nil)
+
+		rec := httptest.NewRecorder()
+		newIdempotencyRouter(t, prService, idempotency).ServeHTTP(rec, newRequest())
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Empty(t, rec.Header().Get(handler.IdempotentReplayHeader))
+	})
+
+	t.Run("Retry is replayed", func(t *testing.T) {
+		stored := []byte(`{"pr":{"pull_request_id":"pr-1"}}` + "\n")
+		idempotency := mocks.NewIdempotencyService(t)
+		idempotency.On("Begin", mock.Anything, "retry-1", "/pullRequest/create", mock.Anything).
+			Return(&domains.IdempotentResponse{StatusCode: http.StatusCreated, Body: stored, Completed: true}, nil)
+
+		rec := httptest.NewRecorder()
+		newIdempotencyRouter(t, mocks.NewPRService(t), idempotency).ServeHTTP(rec, newRequest())
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, stored, rec.Body.Bytes())
+		assert.Equal(t, "true", rec.Header().Get(handler.IdempotentReplayHeader))
+	})
+
+	t.Run("Key reused with another body", func(t *testing.T) {
+		idempotency := mocks.NewIdempotencyService(t)
+		idempotency.On("Begin", mock.Anything, "retry-1", "/pullRequest/create", mock.Anything).
+			Return(nil, service.ErrIdempotencyKeyReused)
+
+		rec := httptest.NewRecorder()
+		newIdempotencyRouter(t, mocks.NewPRService(t), idempotency).ServeHTTP(rec, newRequest())
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("Server error is not stored", func(t *testing.T) {
+		prService := mocks.NewPRService(t)
+		idempotency := mocks.NewIdempotencyService(t)
+		idempotency.On("Begin", mock.Anything, "retry-1", "/pullRequest/create", mock.Anything).Return(nil, nil)
+		prService.On("CreatePR", mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+		idempotency.On("Release", mock.Anything, "retry-1", "/pullRequest/create").Return(nil)
+
+		rec := httptest.NewRecorder()
+		newIdempotencyRouter(t, prService, idempotency).ServeHTTP(rec, newRequest())
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func TestHandler_IdempotentReassignConflict(t *testing.T) {
+	body := []byte(`{"pull_request_id":"pr-1","old_user_id":"u2"}`)
+	prService := mocks.NewPRService(t)
+	idempotency := mocks.NewIdempotencyService(t)
+	idempotency.On("Begin", mock.Anything, "retry-1", "/pullRequest/reassign", mock.Anything).Return(nil, nil)
+	prService.On("UpdateReviewer", mock.Anything, "pr-1", "u2", "").
+		Return(nil, "", service.ErrConcurrentModification)
+	idempotency.On("Release", mock.Anything, "retry-1", "/pullRequest/reassign").Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/reassign", bytes.NewReader(body))
+	req.Header.Set(handler.IdempotencyKeyHeader, "retry-1")
+	rec := httptest.NewRecorder()
+	newIdempotencyRouter(t, prService, idempotency).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), handler.ErrCodeConcurrentUpdate)
+}
+
+func newIdempotencyRouter(t *testing.T, prService *mocks.PRService, idempotency *mocks.IdempotencyService) http.Handler {
+	h := handler.New(mocks.NewTeamService(t), mocks.NewUserService(t), prService,
+		mocks.NewWebhookService(t), mocks.NewIntegrationService(t), idempotency)
+	return h.InitRoutes()
+}
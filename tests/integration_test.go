@@ -0,0 +1,314 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"ReviewerAssignmentService/internal/domains"
+	"ReviewerAssignmentService/internal/handler"
+	"ReviewerAssignmentService/internal/integration"
+	"ReviewerAssignmentService/internal/service"
+	"ReviewerAssignmentService/mocks"
+)
+
+const (
+	githubSecret = "gh-secret"
+	gitlabToken  = "gl-token"
+
+	githubPRID = "github:acme/backend#42"
+	gitlabPRID = "gitlab:acme/payments!7"
+)
+
+func loadFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	body, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return body
+}
+
+func TestIntegration_ReplayFixtures(t *testing.T) {
+	isDraft := func(draft bool) any {
+		return mock.MatchedBy(func(input domains.PullRequestInput) bool {
+			return input.ID == githubPRID && input.AuthorID == "u1" && input.Name == "Add rate limiting to public API" &&
+				input.Draft == draft
+		})
+	}
+
+	testCases := []struct {
+		name     string
+		provider domains.Provider
+		fixture  string
+		event    string
+		setup    func(prService *mocks.PRService, prRepo *mocks.PRRepository, identities *mocks.IdentityRepository)
+		action   domains.ExternalAction
+		ignored  bool
+	}{
+		{
+			name:     "GitHub opened",
+			provider: domains.ProviderGitHub,
+			fixture:  "github/pull_request_opened.json",
+			event:    integration.GitHubPullRequestEvent,
+			setup: func(prService *mocks.PRService, prRepo *mocks.PRRepository, identities *mocks.IdentityRepository) {
+				identities.On("GetUserID", mock.Anything, domains.ProviderGitHub, "octo-alice").Return("u1", nil)
+				prRepo.On("Exists", mock.Anything, githubPRID).Return(false, nil)
+				prService.On("CreatePR", mock.Anything, isDraft(false)).
+					Return(&domains.PullRequest{ID: githubPRID, Status: domains.PRStatusOpen}, nil)
+			},
+			action: domains.ExternalOpened,
+		},
+		{
+			name:     "GitHub opened as draft",
+			provider: domains.ProviderGitHub,
+			fixture:  "github/pull_request_opened_draft.json",
+			event:    integration.GitHubPullRequestEvent,
+			setup: func(prService *mocks.PRService, prRepo *mocks.PRRepository, identities *mocks.IdentityRepository) {
+				identities.On("GetUserID", mock.Anything, domains.ProviderGitHub, "octo-alice").Return("u1", nil)
+				prRepo.On("Exists", mock.Anything, githubPRID).Return(false, nil)
+				prService.On("CreatePR", mock.Anything, isDraft(true)).
+					Return(&domains.PullRequest{ID: githubPRID, Status: domains.PRStatusDraft}, nil)
+			},
+			action: domains.ExternalOpened,
+		},
+		{
+			name:     "GitHub opened redelivered",
+			provider: domains.ProviderGitHub,
+			fixture:  "github/pull_request_opened.json",
+			event:    integration.GitHubPullRequestEvent,
+			setup: func(prService *mocks.PRService, prRepo *mocks.PRRepository, identities *mocks.IdentityRepository) {
+				identities.On("GetUserID", mock.Anything, domains.ProviderGitHub, "octo-alice").Return("u1", nil)
+				prRepo.On("Exists", mock.Anything, githubPRID).Return(true, nil)
+			},
+			action:  domains.ExternalOpened,
+			ignored: true,
+		},
+		{
+			name:     "GitHub ready for review",
+			provider: domains.ProviderGitHub,
+			fixture:  "github/pull_request_ready_for_review.json",
+			event:    integration.GitHubPullRequestEvent,
+			setup: func(prService *mocks.PRService, prRepo *mocks.PRRepository, identities *mocks.IdentityRepository) {
+				identities.On("GetUserID", mock.Anything, domains.ProviderGitHub, "octo-alice").Return("u1", nil)
+				prService.On("MarkReady", mock.Anything, githubPRID, []string(nil)).
+					Return(&domains.PullRequest{ID: githubPRID, Status: domains.PRStatusOpen}, nil)
+			},
+			action: domains.ExternalReady,
+		},
+		{
+			name:     "GitHub merged despite policy",
+			provider: domains.ProviderGitHub,
+			fixture:  "github/pull_request_closed_merged.json",
+			event:    integration.GitHubPullRequestEvent,
+			setup: func(prService *mocks.PRService, prRepo *mocks.PRRepository, identities *mocks.IdentityRepository) {
+				identities.On("GetUserID", mock.Anything, domains.ProviderGitHub, "octo-bob").Return("u2", nil)
+				prService.On("MergePR", mock.Anything, githubPRID).Return(nil, service.ErrMergeBlocked)
+				prService.On("ForceMergePR", mock.Anything, githubPRID, mock.MatchedBy(func(o *domains.MergeOverride) bool {
+					return o.ForcedBy == "u2" && o.Reason == "merged on github"
+				})).Return(&domains.PullRequest{ID: githubPRID, Status: domains.PRStatusMerged}, nil)
+			},
+			action: domains.ExternalMerged,
+		},
+		{
+			name:     "GitHub closed",
+			provider: domains.ProviderGitHub,
+			fixture:  "github/pull_request_closed.json",
+			event:    integration.GitHubPullRequestEvent,
+			setup: func(prService *mocks.PRService, prRepo *mocks.PRRepository, identities *mocks.IdentityRepository) {
+				identities.On("GetUserID", mock.Anything, domains.ProviderGitHub, "octo-alice").Return("", nil)
+				prService.On("ClosePR", mock.Anything, githubPRID).
+					Return(&domains.PullRequest{ID: githubPRID, Status: domains.PRStatusClosed}, nil)
+			},
+			action: domains.ExternalClosed,
+		},
+		{
+			name:     "GitHub reopened",
+			provider: domains.ProviderGitHub,
+			fixture:  "github/pull_request_reopened.json",
+			event:    integration.GitHubPullRequestEvent,
+			setup: func(prService *mocks.PRService, prRepo *mocks.PRRepository, identities *mocks.IdentityRepository) {
+				identities.On("GetUserID", mock.Anything, domains.ProviderGitHub, "octo-alice").Return("u1", nil)
+				prService.On("ReopenPR", mock.Anything, githubPRID).
+					Return(&domains.PullRequest{ID: githubPRID, Status: domains.PRStatusReopened}, nil)
+			},
+			action: domains.ExternalReopened,
+		},
+		{
+			name:     "GitHub irrelevant action",
+			provider: domains.ProviderGitHub,
+			fixture:  "github/pull_request_labeled.json",
+			event:    integration.GitHubPullRequestEvent,
+			setup:    func(*mocks.PRService, *mocks.PRRepository, *mocks.IdentityRepository) {},
+			ignored:  true,
+		},
+		{
+			name:     "GitHub ping",
+			provider: domains.ProviderGitHub,
+			fixture:  "github/pull_request_opened.json",
+			event:    "ping",
+			setup:    func(*mocks.PRService, *mocks.PRRepository, *mocks.IdentityRepository) {},
+			ignored:  true,
+		},
+		{
+			name:     "GitLab open draft",
+			provider: domains.ProviderGitLab,
+			fixture:  "gitlab/merge_request_open.json",
+			event:    integration.GitLabMergeRequestEvent,
+			setup: func(prService *mocks.PRService, prRepo *mocks.PRRepository, identities *mocks.IdentityRepository) {
+				identities.On("GetUserID", mock.Anything, domains.ProviderGitLab, "alice.doe").Return("u1", nil)
+				prRepo.On("Exists", mock.Anything, gitlabPRID).Return(false, nil)
+				prService.On("CreatePR", mock.Anything, mock.MatchedBy(func(input domains.PullRequestInput) bool {
+					return input.ID == gitlabPRID && input.AuthorID == "u1" && input.Draft
+				})).Return(&domains.PullRequest{ID: gitlabPRID, Status: domains.PRStatusDraft}, nil)
+			},
+			action: domains.ExternalOpened,
+		},
+		{
+			name:     "GitLab marked ready",
+			provider: domains.ProviderGitLab,
+			fixture:  "gitlab/merge_request_update_ready.json",
+			event:    integration.GitLabMergeRequestEvent,
+			setup: func(prService *mocks.PRService, prRepo *mocks.PRRepository, identities *mocks.IdentityRepository) {
+				identities.On("GetUserID", mock.Anything, domains.ProviderGitLab, "alice.doe").Return("u1", nil)
+				prService.On("MarkReady", mock.Anything, gitlabPRID, []string(nil)).
+					Return(&domains.PullRequest{ID: gitlabPRID, Status: domains.PRStatusOpen}, nil)
+			},
+			action: domains.ExternalReady,
+		},
+		{
+			name:     "GitLab merged",
+			provider: domains.ProviderGitLab,
+			fixture:  "gitlab/merge_request_merge.json",
+			event:    integration.GitLabMergeRequestEvent,
+			setup: func(prService *mocks.PRService, prRepo *mocks.PRRepository, identities *mocks.IdentityRepository) {
+				identities.On("GetUserID", mock.Anything, domains.ProviderGitLab, "bob.roe").Return("u2", nil)
+				prService.On("MergePR", mock.Anything, gitlabPRID).
+					Return(&domains.PullRequest{ID: gitlabPRID, Status: domains.PRStatusMerged}, nil)
+			},
+			action: domains.ExternalMerged,
+		},
+		{
+			name:     "GitLab closed",
+			provider: domains.ProviderGitLab,
+			fixture:  "gitlab/merge_request_close.json",
+			event:    integration.GitLabMergeRequestEvent,
+			setup: func(prService *mocks.PRService, prRepo *mocks.PRRepository, identities *mocks.IdentityRepository) {
+				identities.On("GetUserID", mock.Anything, domains.ProviderGitLab, "alice.doe").Return("u1", nil)
+				prService.On("ClosePR", mock.Anything, gitlabPRID).
+					Return(&domains.PullRequest{ID: gitlabPRID, Status: domains.PRStatusClosed}, nil)
+			},
+			action: domains.ExternalClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			prService := mocks.NewPRService(t)
+			prRepo := mocks.NewPRRepository(t)
+			identities := mocks.NewIdentityRepository(t)
+			tc.setup(prService, prRepo, identities)
+
+			router := newIntegrationRouter(t, prService, prRepo, identities)
+			body := loadFixture(t, tc.fixture)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, signedRequest(tc.provider, tc.event, body))
+
+			require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+			var result domains.IntegrationResult
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+			assert.Equal(t, tc.provider, result.Provider)
+			assert.Equal(t, tc.action, result.Action)
+			assert.Equal(t, tc.ignored, result.Ignored)
+		})
+	}
+}
+
+func TestIntegration_Verification(t *testing.T) {
+	body := loadFixture(t, "github/pull_request_opened.json")
+
+	testCases := []struct {
+		name   string
+		req    *http.Request
+		status int
+	}{
+		{
+			name: "GitHub wrong signature",
+			req: func() *http.Request {
+				req := signedRequest(domains.ProviderGitHub, integration.GitHubPullRequestEvent, body)
+				req.Header.Set(integration.GitHubSignatureHeader, service.WebhookSignature("other", body))
+				return req
+			}(),
+			status: http.StatusUnauthorized,
+		},
+		{
+			name: "GitHub missing signature",
+			req: func() *http.Request {
+				req := signedRequest(domains.ProviderGitHub, integration.GitHubPullRequestEvent, body)
+				req.Header.Del(integration.GitHubSignatureHeader)
+				return req
+			}(),
+			status: http.StatusUnauthorized,
+		},
+		{
+			name: "GitLab wrong token",
+			req: func() *http.Request {
+				req := signedRequest(domains.ProviderGitLab, integration.GitLabMergeRequestEvent, body)
+				req.Header.Set(integration.GitLabTokenHeader, "guess")
+				return req
+			}(),
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := newIntegrationRouter(t, mocks.NewPRService(t), mocks.NewPRRepository(t), mocks.NewIdentityRepository(t))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, tc.req)
+			assert.Equal(t, tc.status, rec.Code)
+		})
+	}
+}
+
+func TestIntegration_UnknownAuthor(t *testing.T) {
+	prRepo := mocks.NewPRRepository(t)
+	identities := mocks.NewIdentityRepository(t)
+	identities.On("GetUserID", mock.Anything, domains.ProviderGitHub, "octo-alice").Return("", nil)
+	prRepo.On("Exists", mock.Anything, githubPRID).Return(false, nil)
+
+	router := newIntegrationRouter(t, mocks.NewPRService(t), prRepo, identities)
+	rec := httptest.NewRecorder()
+	body := loadFixture(t, "github/pull_request_opened.json")
+	router.ServeHTTP(rec, signedRequest(domains.ProviderGitHub, integration.GitHubPullRequestEvent, body))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+}
+
+func newIntegrationRouter(t *testing.T, prService *mocks.PRService,
+	prRepo *mocks.PRRepository, identities *mocks.IdentityRepository) http.Handler {
+	integrations := service.NewIntegrationService(prService, prRepo, mocks.NewUserRepository(t), identities,
+		service.IntegrationConfig{GitHubSecret: githubSecret, GitLabToken: gitlabToken})
+	h := handler.New(mocks.NewTeamService(t), mocks.NewUserService(t), prService,
+		mocks.NewWebhookService(t), integrations, mocks.NewIdempotencyService(t))
+	return h.InitRoutes()
+}
+
+func signedRequest(provider domains.Provider, event string, body []byte) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/integrations/"+string(provider), bytes.NewReader(body))
+	switch provider {
+	case domains.ProviderGitHub:
+		req.Header.Set(integration.GitHubEventHeader, event)
+		req.Header.Set(integration.GitHubSignatureHeader, service.WebhookSignature(githubSecret, body))
+	case domains.ProviderGitLab:
+		req.Header.Set(integration.GitLabEventHeader, event)
+		req.Header.Set(integration.GitLabTokenHeader, gitlabToken)
+	}
+	return req
+}
@@ -2,6 +2,8 @@ package tests
 
 import (
 	"context"
+	"math/rand/v2"
+	"slices"
 	"testing"
 	"time"
 
@@ -9,8 +11,10 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"ReviewerAssignmentService/internal/domains"
+	"ReviewerAssignmentService/internal/repository"
 	"ReviewerAssignmentService/internal/service"
 )
 
@@ -18,9 +22,11 @@ func TestPRService_CreatePR(t *testing.T) {
 	testCases := []struct {
 		name              string
 		input             domains.PullRequestInput
-		setupMocks        func(pr *mocks.PRRepository, u *mocks.UserRepository)
+		setupMocks        func(pr *mocks.PRRepository, u *mocks.UserRepository, tm *mocks.TeamRepository, o *mocks.OwnershipRepository)
 		expectError       bool
 		expectedReviewers []string
+		expectedPolicy    string
+		expectNotes       bool
 	}{
 		{
 			name: "OK: 2 revs",
@@ -29,19 +35,225 @@ func TestPRService_CreatePR(t *testing.T) {
 				Name:     "Feature 1",
 				AuthorID: "u1",
 			},
-			setupMocks: func(pr *mocks.PRRepository, u *mocks.UserRepository) {
+			setupMocks: func(pr *mocks.PRRepository, u *mocks.UserRepository, tm *mocks.TeamRepository, o *mocks.OwnershipRepository) {
 				u.On("GetByID", mock.Anything, "u1").Return(&domains.User{
 					ID:       "u1",
 					TeamName: "T1",
 				}, nil)
 
-				u.On("GetRandomActiveUsersByTeam", mock.Anything, "T1", "u1", 2).
-					Return([]string{"r1", "r2"}, nil)
+				tm.On("GetSettings", mock.Anything, "T1").Return(&domains.TeamSettings{}, nil)
+				u.On("GetActiveCandidatesByTeam", mock.Anything, "T1", []string{"u1"}).
+					Return([]domains.ReviewCandidate{{UserID: "r1"}, {UserID: "r2"}}, nil)
 
 				pr.On("Create", mock.Anything, mock.Anything).Return(nil)
 			},
 			expectError:       false,
 			expectedReviewers: []string{"r1", "r2"},
+			expectedPolicy:    service.StrategyLeastLoaded,
+		},
+		{
+			name: "OK: team strategy prefers least loaded",
+			input: domains.PullRequestInput{
+				ID:       "pr2",
+				Name:     "Feature 2",
+				AuthorID: "u1",
+			},
+			setupMocks: func(pr *mocks.PRRepository, u *mocks.UserRepository, tm *mocks.TeamRepository, o *mocks.OwnershipRepository) {
+				u.On("GetByID", mock.Anything, "u1").Return(&domains.User{
+					ID:       "u1",
+					TeamName: "T1",
+				}, nil)
+
+				tm.On("GetSettings", mock.Anything, "T1").Return(&domains.TeamSettings{
+					ReviewerStrategy: service.StrategyLeastLoaded,
+				}, nil)
+				u.On("GetActiveCandidatesByTeam", mock.Anything, "T1", []string{"u1"}).
+					Return([]domains.ReviewCandidate{
+						{UserID: "busy", OpenReviews: 4},
+						{UserID: "r1", OpenReviews: 1},
+						{UserID: "r2", OpenReviews: 0},
+					}, nil)
+
+				pr.On("Create", mock.Anything, mock.Anything).Return(nil)
+			},
+			expectError:       false,
+			expectedReviewers: []string{"r2", "r1"},
+			expectedPolicy:    service.StrategyLeastLoaded,
+		},
+		{
+			name: "OK: team review limit skips overloaded reviewers",
+			input: domains.PullRequestInput{
+				ID:       "pr3",
+				Name:     "Feature 3",
+				AuthorID: "u1",
+			},
+			setupMocks: func(pr *mocks.PRRepository, u *mocks.UserRepository, tm *mocks.TeamRepository, o *mocks.OwnershipRepository) {
+				u.On("GetByID", mock.Anything, "u1").Return(&domains.User{
+					ID:       "u1",
+					TeamName: "T1",
+				}, nil)
+
+				limit := 3
+				tm.On("GetSettings", mock.Anything, "T1").Return(&domains.TeamSettings{
+					MaxOpenReviews: &limit,
+				}, nil)
+				u.On("GetActiveCandidatesByTeam", mock.Anything, "T1", []string{"u1"}).
+					Return([]domains.ReviewCandidate{
+						{UserID: "busy", OpenReviews: 3},
+						{UserID: "r1", OpenReviews: 2},
+					}, nil)
+
+				pr.On("Create", mock.Anything, mock.Anything).Return(nil)
+			},
+			expectError:       false,
+			expectedReviewers: []string{"r1"},
+			expectedPolicy:    service.StrategyLeastLoaded,
+			expectNotes:       true,
+		},
+		{
+			name: "OK: team requires one reviewer",
+			input: domains.PullRequestInput{
+				ID:       "pr4",
+				Name:     "Docs",
+				AuthorID: "u1",
+			},
+			setupMocks: func(pr *mocks.PRRepository, u *mocks.UserRepository, tm *mocks.TeamRepository, o *mocks.OwnershipRepository) {
+				u.On("GetByID", mock.Anything, "u1").Return(&domains.User{
+					ID:       "u1",
+					TeamName: "Docs",
+				}, nil)
+
+				tm.On("GetSettings", mock.Anything, "Docs").Return(&domains.TeamSettings{
+					RequiredReviewers: 1,
+				}, nil)
+				u.On("GetActiveCandidatesByTeam", mock.Anything, "Docs", []string{"u1"}).
+					Return([]domains.ReviewCandidate{{UserID: "r1"}, {UserID: "r2"}}, nil)
+
+				pr.On("Create", mock.Anything, mock.Anything).Return(nil)
+			},
+			expectError:       false,
+			expectedReviewers: []string{"r1"},
+			expectedPolicy:    service.StrategyLeastLoaded,
+		},
+		{
+			name: "OK: team requires three reviewers",
+			input: domains.PullRequestInput{
+				ID:       "pr5",
+				Name:     "Platform",
+				AuthorID: "u1",
+			},
+			setupMocks: func(pr *mocks.PRRepository, u *mocks.UserRepository, tm *mocks.TeamRepository, o *mocks.OwnershipRepository) {
+				u.On("GetByID", mock.Anything, "u1").Return(&domains.User{
+					ID:       "u1",
+					TeamName: "Platform",
+				}, nil)
+
+				tm.On("GetSettings", mock.Anything, "Platform").Return(&domains.TeamSettings{
+					RequiredReviewers: 3,
+				}, nil)
+				u.On("GetActiveCandidatesByTeam", mock.Anything, "Platform", []string{"u1"}).
+					Return([]domains.ReviewCandidate{{UserID: "r1"}, {UserID: "r2"}, {UserID: "r3"}, {UserID: "r4"}}, nil)
+
+				pr.On("Create", mock.Anything, mock.Anything).Return(nil)
+			},
+			expectError:       false,
+			expectedReviewers: []string{"r1", "r2", "r3"},
+			expectedPolicy:    service.StrategyLeastLoaded,
+		},
+		{
+			name: "OK: code owner picked first",
+			input: domains.PullRequestInput{
+				ID:           "pr6",
+				Name:         "Billing",
+				AuthorID:     "u1",
+				ChangedFiles: []string{"billing/invoice.go", "README.md"},
+			},
+			setupMocks: func(pr *mocks.PRRepository, u *mocks.UserRepository, tm *mocks.TeamRepository, o *mocks.OwnershipRepository) {
+				u.On("GetByID", mock.Anything, "u1").Return(&domains.User{
+					ID:       "u1",
+					TeamName: "T1",
+				}, nil)
+
+				tm.On("GetSettings", mock.Anything, "T1").Return(&domains.TeamSettings{}, nil)
+				o.On("GetRules", mock.Anything, "T1").Return([]domains.OwnershipRule{
+					{Pattern: "*", Owners: []string{"lead"}},
+					{Pattern: "/billing/", Owners: []string{"owner"}},
+				}, nil)
+				u.On("GetActiveCandidatesByIDs", mock.Anything, []string{"owner", "lead"}, []string{"u1"}).
+					Return([]domains.ReviewCandidate{{UserID: "owner", OpenReviews: 1}, {UserID: "lead", OpenReviews: 2}}, nil)
+				u.On("GetActiveCandidatesByTeam", mock.Anything, "T1", []string{"u1", "owner"}).
+					Return([]domains.ReviewCandidate{{UserID: "r1"}}, nil)
+
+				pr.On("Create", mock.Anything, mock.MatchedBy(func(p *domains.PullRequest) bool {
+					return assert.ObjectsAreEqual([]string{"owner"}, p.OwnerReviewers)
+				})).Return(nil)
+			},
+			expectError:       false,
+			expectedReviewers: []string{"owner", "r1"},
+			expectedPolicy:    service.StrategyLeastLoaded,
+		},
+		{
+			name: "OK: no owner available",
+			input: domains.PullRequestInput{
+				ID:           "pr7",
+				Name:         "Billing",
+				AuthorID:     "owner",
+				ChangedFiles: []string{"billing/invoice.go"},
+			},
+			setupMocks: func(pr *mocks.PRRepository, u *mocks.UserRepository, tm *mocks.TeamRepository, o *mocks.OwnershipRepository) {
+				u.On("GetByID", mock.Anything, "owner").Return(&domains.User{
+					ID:       "owner",
+					TeamName: "T1",
+				}, nil)
+
+				tm.On("GetSettings", mock.Anything, "T1").Return(&domains.TeamSettings{}, nil)
+				o.On("GetRules", mock.Anything, "T1").Return([]domains.OwnershipRule{
+					{Pattern: "billing/", Owners: []string{"owner"}},
+				}, nil)
+				u.On("GetActiveCandidatesByIDs", mock.Anything, []string{"owner"}, []string{"owner"}).
+					Return([]domains.ReviewCandidate{}, nil)
+				u.On("GetActiveCandidatesByTeam", mock.Anything, "T1", []string{"owner"}).
+					Return([]domains.ReviewCandidate{{UserID: "r1"}, {UserID: "r2"}}, nil)
+
+				pr.On("Create", mock.Anything, mock.Anything).Return(nil)
+			},
+			expectError:       false,
+			expectedReviewers: []string{"r1", "r2"},
+			expectedPolicy:    service.StrategyLeastLoaded,
+			expectNotes:       true,
+		},
+		{
+			name: "OK: missing slots filled from fallback teams",
+			input: domains.PullRequestInput{
+				ID:       "pr8",
+				Name:     "Small team",
+				AuthorID: "u1",
+			},
+			setupMocks: func(pr *mocks.PRRepository, u *mocks.UserRepository, tm *mocks.TeamRepository, o *mocks.OwnershipRepository) {
+				u.On("GetByID", mock.Anything, "u1").Return(&domains.User{
+					ID:       "u1",
+					TeamName: "Small",
+				}, nil)
+
+				tm.On("GetSettings", mock.Anything, "Small").Return(&domains.TeamSettings{
+					FallbackTeams: []string{"Empty", "Backup"},
+				}, nil)
+				tm.On("GetSettings", mock.Anything, "Empty").Return(&domains.TeamSettings{}, nil)
+				tm.On("GetSettings", mock.Anything, "Backup").Return(&domains.TeamSettings{}, nil)
+				u.On("GetActiveCandidatesByTeam", mock.Anything, "Small", []string{"u1"}).
+					Return([]domains.ReviewCandidate{{UserID: "r1"}}, nil)
+				u.On("GetActiveCandidatesByTeam", mock.Anything, "Empty", []string{"u1", "r1"}).
+					Return([]domains.ReviewCandidate{}, nil)
+				u.On("GetActiveCandidatesByTeam", mock.Anything, "Backup", []string{"u1", "r1"}).
+					Return([]domains.ReviewCandidate{{UserID: "b1"}, {UserID: "b2"}}, nil)
+
+				pr.On("Create", mock.Anything, mock.MatchedBy(func(p *domains.PullRequest) bool {
+					return assert.ObjectsAreEqual([]string{"b1"}, p.FallbackReviewers)
+				})).Return(nil)
+			},
+			expectError:       false,
+			expectedReviewers: []string{"r1", "b1"},
+			expectedPolicy:    service.StrategyLeastLoaded,
 		},
 	}
 
@@ -49,44 +261,66 @@ func TestPRService_CreatePR(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			mockPRRepo := mocks.NewPRRepository(t)
 			mockUserRepo := mocks.NewUserRepository(t)
-			tc.setupMocks(mockPRRepo, mockUserRepo)
+			mockTeamRepo := mocks.NewTeamRepository(t)
+			mockOwnershipRepo := mocks.NewOwnershipRepository(t)
+			tc.setupMocks(mockPRRepo, mockUserRepo, mockTeamRepo, mockOwnershipRepo)
 
-			res, err := service.NewPRService(mockPRRepo, mockUserRepo).CreatePR(context.Background(), tc.input)
+			res, err := newPRService(t,
+				mockPRRepo, mockUserRepo, mockTeamRepo, mockOwnershipRepo, service.AssignmentConfig{},
+			).CreatePR(context.Background(), tc.input)
 
 			if tc.expectError {
 				assert.Error(t, err)
 			} else {
 				assert.Equal(t, tc.expectedReviewers, res.AssignedReviewers)
+				assert.Equal(t, tc.expectedPolicy, res.AssignmentPolicy)
+				assert.Equal(t, tc.expectNotes, len(res.AssignmentNotes) > 0)
 			}
 		})
 	}
 }
 
 func TestPRService_MergePR(t *testing.T) {
+	apiOwners := []domains.OwnershipRule{
+		{Pattern: "api/", Owners: []string{"o1"}},
+		{Pattern: "docs/", Owners: []string{"@Writers"}},
+	}
+
+	approved := func(ids ...string) []domains.Review {
+		reviews := make([]domains.Review, 0, len(ids))
+		for _, id := range ids {
+			reviews = append(reviews, domains.Review{ReviewerID: id, State: domains.ReviewStateApproved})
+		}
+		return reviews
+	}
+
 	testCases := []struct {
 		name           string
 		prID           string
-		setup          func(prRepo *mocks.PRRepository)
+		setup          func(prRepo *mocks.PRRepository, userRepo *mocks.UserRepository, teamRepo *mocks.TeamRepository, ownershipRepo *mocks.OwnershipRepository)
 		expectedStatus domains.PRStatus
-		expectError    bool
+		expectError    error
+		expectMessage  string
 	}{
 		{
 			name: "OK: Merge open PR",
 			prID: "pr-open",
-			setup: func(prRepo *mocks.PRRepository) {
+			setup: func(prRepo *mocks.PRRepository, userRepo *mocks.UserRepository, teamRepo *mocks.TeamRepository, ownershipRepo *mocks.OwnershipRepository) {
 				prRepo.On("GetByID", mock.Anything, "pr-open").Return(&domains.PullRequest{
-					ID:     "pr-open",
-					Status: domains.PRStatusOpen,
+					ID:       "pr-open",
+					AuthorID: "a1",
+					Status:   domains.PRStatusOpen,
 				}, nil)
+				userRepo.On("GetByID", mock.Anything, "a1").Return(&domains.User{ID: "a1", TeamName: "Team"}, nil)
+				teamRepo.On("GetSettings", mock.Anything, "Team").Return(&domains.TeamSettings{}, nil)
 				prRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
 			},
 			expectedStatus: domains.PRStatusMerged,
-			expectError:    false,
 		},
 		{
 			name: "Idempotency: PR already merged",
 			prID: "pr-merged",
-			setup: func(prRepo *mocks.PRRepository) {
+			setup: func(prRepo *mocks.PRRepository, userRepo *mocks.UserRepository, teamRepo *mocks.TeamRepository, ownershipRepo *mocks.OwnershipRepository) {
 				now := time.Now()
 				prRepo.On("GetByID", mock.Anything, "pr-merged").Return(&domains.PullRequest{
 					ID:       "pr-merged",
@@ -95,20 +329,109 @@ func TestPRService_MergePR(t *testing.T) {
 				}, nil)
 			},
 			expectedStatus: domains.PRStatusMerged,
-			expectError:    false,
+		},
+		{
+			name: "OK: Policy satisfied",
+			prID: "pr-ok",
+			setup: func(prRepo *mocks.PRRepository, userRepo *mocks.UserRepository, teamRepo *mocks.TeamRepository, ownershipRepo *mocks.OwnershipRepository) {
+				prRepo.On("GetByID", mock.Anything, "pr-ok").Return(&domains.PullRequest{
+					ID:             "pr-ok",
+					AuthorID:       "a1",
+					Status:         domains.PRStatusOpen,
+					OwnerReviewers: []string{"o1"},
+					ChangedFiles:   []string{"api/handler.go"},
+					Reviews:        approved("o1", "r1"),
+				}, nil)
+				ownershipRepo.On("GetRules", mock.Anything, "Team").Return(apiOwners, nil)
+				userRepo.On("GetByID", mock.Anything, "a1").Return(&domains.User{ID: "a1", TeamName: "Team"}, nil)
+				teamRepo.On("GetSettings", mock.Anything, "Team").Return(&domains.TeamSettings{
+					MergePolicy: domains.MergePolicy{MinApprovals: 2, RequireOwnerApproval: true},
+				}, nil)
+				prRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+			},
+			expectedStatus: domains.PRStatusMerged,
+		},
+		{
+			name: "Error: Policy not satisfied",
+			prID: "pr-blocked",
+			setup: func(prRepo *mocks.PRRepository, userRepo *mocks.UserRepository, teamRepo *mocks.TeamRepository, ownershipRepo *mocks.OwnershipRepository) {
+				prRepo.On("GetByID", mock.Anything, "pr-blocked").Return(&domains.PullRequest{
+					ID:             "pr-blocked",
+					AuthorID:       "a1",
+					Status:         domains.PRStatusOpen,
+					OwnerReviewers: []string{"o1"},
+					ChangedFiles:   []string{"api/handler.go"},
+					Reviews: append(approved("r1"),
+						domains.Review{ReviewerID: "o1", State: domains.ReviewStateChangesRequested}),
+				}, nil)
+				ownershipRepo.On("GetRules", mock.Anything, "Team").Return(apiOwners, nil)
+				userRepo.On("GetByID", mock.Anything, "a1").Return(&domains.User{ID: "a1", TeamName: "Team"}, nil)
+				teamRepo.On("GetSettings", mock.Anything, "Team").Return(&domains.TeamSettings{
+					MergePolicy: domains.MergePolicy{MinApprovals: 2, RequireOwnerApproval: true},
+				}, nil)
+			},
+			expectError: service.ErrMergeBlocked,
+			expectMessage: "merge requirements not met: 1 of 2 required approvals; " +
+				"changes requested by o1; no approval from a code owner",
+		},
+		{
+			name: "Error: Owner reassigned away",
+			prID: "pr-reassigned",
+			setup: func(prRepo *mocks.PRRepository, userRepo *mocks.UserRepository, teamRepo *mocks.TeamRepository, ownershipRepo *mocks.OwnershipRepository) {
+				prRepo.On("GetByID", mock.Anything, "pr-reassigned").Return(&domains.PullRequest{
+					ID:                "pr-reassigned",
+					AuthorID:          "a1",
+					Status:            domains.PRStatusOpen,
+					AssignedReviewers: []string{"r1", "r2"},
+					ChangedFiles:      []string{"api/handler.go"},
+					Reviews:           approved("r1", "r2"),
+				}, nil)
+				userRepo.On("GetByID", mock.Anything, "a1").Return(&domains.User{ID: "a1", TeamName: "Team"}, nil)
+				teamRepo.On("GetSettings", mock.Anything, "Team").Return(&domains.TeamSettings{
+					MergePolicy: domains.MergePolicy{MinApprovals: 2, RequireOwnerApproval: true},
+				}, nil)
+				ownershipRepo.On("GetRules", mock.Anything, "Team").Return(apiOwners, nil)
+			},
+			expectError:   service.ErrMergeBlocked,
+			expectMessage: "merge requirements not met: no approval from a code owner",
+		},
+		{
+			name: "OK: Approved by a member of the owning team",
+			prID: "pr-team-owner",
+			setup: func(prRepo *mocks.PRRepository, userRepo *mocks.UserRepository, teamRepo *mocks.TeamRepository, ownershipRepo *mocks.OwnershipRepository) {
+				prRepo.On("GetByID", mock.Anything, "pr-team-owner").Return(&domains.PullRequest{
+					ID:           "pr-team-owner",
+					AuthorID:     "a1",
+					Status:       domains.PRStatusOpen,
+					ChangedFiles: []string{"docs/readme.md"},
+					Reviews:      approved("w1"),
+				}, nil)
+				userRepo.On("GetByID", mock.Anything, "a1").Return(&domains.User{ID: "a1", TeamName: "Team"}, nil)
+				userRepo.On("GetByID", mock.Anything, "w1").Return(&domains.User{ID: "w1", TeamName: "Writers"}, nil)
+				teamRepo.On("GetSettings", mock.Anything, "Team").Return(&domains.TeamSettings{
+					MergePolicy: domains.MergePolicy{RequireOwnerApproval: true},
+				}, nil)
+				ownershipRepo.On("GetRules", mock.Anything, "Team").Return(apiOwners, nil)
+				prRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+			},
+			expectedStatus: domains.PRStatusMerged,
 		},
 	}
 
 	for _, ts := range testCases {
 		t.Run(ts.name, func(t *testing.T) {
 			prRepo := mocks.NewPRRepository(t)
-			ts.setup(prRepo)
+			userRepo := mocks.NewUserRepository(t)
+			teamRepo := mocks.NewTeamRepository(t)
+			ownershipRepo := mocks.NewOwnershipRepository(t)
+			ts.setup(prRepo, userRepo, teamRepo, ownershipRepo)
 
-			svc := service.NewPRService(prRepo, mocks.NewUserRepository(t))
+			svc := newPRService(t, prRepo, userRepo, teamRepo, ownershipRepo, service.AssignmentConfig{})
 			result, err := svc.MergePR(context.Background(), ts.prID)
 
-			if ts.expectError {
-				assert.Error(t, err)
+			if ts.expectError != nil {
+				assert.ErrorIs(t, err, ts.expectError)
+				assert.EqualError(t, err, ts.expectMessage)
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, ts.expectedStatus, result.Status)
@@ -117,12 +440,59 @@ func TestPRService_MergePR(t *testing.T) {
 	}
 }
 
+func TestPRService_ForceMergePR(t *testing.T) {
+	prRepo := mocks.NewPRRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	teamRepo := mocks.NewTeamRepository(t)
+
+	prRepo.On("GetByID", mock.Anything, "pr1").Return(&domains.PullRequest{
+		ID: "pr1", AuthorID: "a1", Status: domains.PRStatusOpen,
+	}, nil)
+	userRepo.On("GetByID", mock.Anything, "a1").Return(&domains.User{ID: "a1", TeamName: "Team"}, nil)
+	teamRepo.On("GetSettings", mock.Anything, "Team").Return(&domains.TeamSettings{
+		MergePolicy: domains.MergePolicy{MinApprovals: 1},
+	}, nil)
+	prRepo.On("MergeWithOverride", mock.Anything, mock.Anything, &domains.MergeOverride{
+		PullRequestID:   "pr1",
+		ForcedBy:        "admin",
+		Reason:          "hotfix",
+		UnmetConditions: []string{"0 of 1 required approvals"},
+	}).Return(nil)
+
+	svc := newPRService(t, prRepo, userRepo, teamRepo, mocks.NewOwnershipRepository(t),
+		service.AssignmentConfig{MergeAdmins: []string{"admin"}})
+
+	_, err := svc.ForceMergePR(context.Background(), "pr1", &domains.MergeOverride{})
+	assert.ErrorIs(t, err, service.ErrOverrideActorRequired)
+
+	_, err = svc.ForceMergePR(domains.WithActor(context.Background(), "dev"), "pr1",
+		&domains.MergeOverride{ForcedBy: "dev", Reason: "hotfix"})
+	assert.ErrorIs(t, err, service.ErrOverrideForbidden, "only merge admins may force a merge")
+
+	_, err = svc.ForceMergePR(domains.WithActor(context.Background(), "dev"), "pr1",
+		&domains.MergeOverride{ForcedBy: "admin", Reason: "hotfix"})
+	assert.ErrorIs(t, err, service.ErrOverrideForbidden, "the actor may not force a merge in an admin's name")
+
+	_, err = svc.ForceMergePR(context.Background(), "pr1", &domains.MergeOverride{ForcedBy: "admin", Reason: "hotfix"})
+	assert.ErrorIs(t, err, service.ErrOverrideForbidden, "forced_by alone does not identify the caller")
+
+	_, err = svc.ForceMergePR(domains.WithActor(context.Background(), "admin"), "pr1",
+		&domains.MergeOverride{ForcedBy: "other", Reason: "hotfix"})
+	assert.ErrorIs(t, err, service.ErrOverrideForbidden, "an admin may not force a merge in someone else's name")
+
+	pr, err := svc.ForceMergePR(domains.WithActor(context.Background(), "admin"), "pr1",
+		&domains.MergeOverride{ForcedBy: "admin", Reason: "hotfix"})
+	assert.NoError(t, err)
+	assert.Equal(t, domains.PRStatusMerged, pr.Status)
+}
+
 func TestPRService_UpdateReviewer(t *testing.T) {
 	testCases := []struct {
 		name        string
 		prID        string
 		oldID       string
-		setup       func(prRepo *mocks.PRRepository, userRepo *mocks.UserRepository)
+		newID       string
+		setup       func(prRepo *mocks.PRRepository, userRepo *mocks.UserRepository, teamRepo *mocks.TeamRepository)
 		expectedID  string
 		expectError error
 	}{
@@ -130,7 +500,7 @@ func TestPRService_UpdateReviewer(t *testing.T) {
 			name:  "OK: Reassign reviewer",
 			prID:  "pr1",
 			oldID: "old",
-			setup: func(prRepo *mocks.PRRepository, userRepo *mocks.UserRepository) {
+			setup: func(prRepo *mocks.PRRepository, userRepo *mocks.UserRepository, teamRepo *mocks.TeamRepository) {
 				prRepo.On("GetByID", mock.Anything, "pr1").Return(&domains.PullRequest{
 					ID:                "pr1",
 					AuthorID:          "author",
@@ -143,8 +513,9 @@ func TestPRService_UpdateReviewer(t *testing.T) {
 					TeamName: "Team",
 				}, nil)
 
-				userRepo.On("GetRandomActiveUsersByTeam", mock.Anything, "Team", "old", 5).
-					Return([]string{"author", "second", "new"}, nil)
+				teamRepo.On("GetSettings", mock.Anything, "Team").Return(&domains.TeamSettings{}, nil)
+				userRepo.On("GetActiveCandidatesByTeam", mock.Anything, "Team", []string{"author", "old", "second"}).
+					Return([]domains.ReviewCandidate{{UserID: "new"}}, nil)
 
 				prRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
 			},
@@ -155,7 +526,7 @@ func TestPRService_UpdateReviewer(t *testing.T) {
 			name:  "Fail: Cannot reassign on merged pr",
 			prID:  "pr2",
 			oldID: "u1",
-			setup: func(prRepo *mocks.PRRepository, userRepo *mocks.UserRepository) {
+			setup: func(prRepo *mocks.PRRepository, userRepo *mocks.UserRepository, teamRepo *mocks.TeamRepository) {
 				prRepo.On("GetByID", mock.Anything, "pr2").Return(&domains.PullRequest{
 					ID:     "pr2",
 					Status: domains.PRStatusMerged,
@@ -164,16 +535,148 @@ func TestPRService_UpdateReviewer(t *testing.T) {
 			expectedID:  "",
 			expectError: service.ErrPRMerged,
 		},
+		{
+			name:  "Fail: All candidates reached review limit",
+			prID:  "pr3",
+			oldID: "old",
+			setup: func(prRepo *mocks.PRRepository, userRepo *mocks.UserRepository, teamRepo *mocks.TeamRepository) {
+				prRepo.On("GetByID", mock.Anything, "pr3").Return(&domains.PullRequest{
+					ID:                "pr3",
+					AuthorID:          "author",
+					Status:            domains.PRStatusOpen,
+					AssignedReviewers: []string{"old"},
+				}, nil)
+
+				userRepo.On("GetByID", mock.Anything, "old").Return(&domains.User{
+					ID:       "old",
+					TeamName: "Team",
+				}, nil)
+
+				limit := 1
+				teamRepo.On("GetSettings", mock.Anything, "Team").Return(&domains.TeamSettings{
+					MaxOpenReviews: &limit,
+				}, nil)
+				userRepo.On("GetActiveCandidatesByTeam", mock.Anything, "Team", []string{"author", "old"}).
+					Return([]domains.ReviewCandidate{{UserID: "busy", OpenReviews: 1}}, nil)
+			},
+			expectedID:  "",
+			expectError: service.ErrReviewLimitReached,
+		},
+		{
+			name:  "OK: Replacement from fallback team",
+			prID:  "pr4",
+			oldID: "old",
+			setup: func(prRepo *mocks.PRRepository, userRepo *mocks.UserRepository, teamRepo *mocks.TeamRepository) {
+				prRepo.On("GetByID", mock.Anything, "pr4").Return(&domains.PullRequest{
+					ID:                "pr4",
+					AuthorID:          "author",
+					Status:            domains.PRStatusOpen,
+					AssignedReviewers: []string{"old"},
+				}, nil)
+
+				userRepo.On("GetByID", mock.Anything, "old").Return(&domains.User{
+					ID:       "old",
+					TeamName: "Team",
+				}, nil)
+
+				teamRepo.On("GetSettings", mock.Anything, "Team").Return(&domains.TeamSettings{
+					FallbackTeams: []string{"Backup"},
+				}, nil)
+				teamRepo.On("GetSettings", mock.Anything, "Backup").Return(&domains.TeamSettings{}, nil)
+				userRepo.On("GetActiveCandidatesByTeam", mock.Anything, "Team", []string{"author", "old"}).
+					Return([]domains.ReviewCandidate{}, nil)
+				userRepo.On("GetActiveCandidatesByTeam", mock.Anything, "Backup", []string{"author", "old"}).
+					Return([]domains.ReviewCandidate{{UserID: "backup"}}, nil)
+
+				prRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+			},
+			expectedID:  "backup",
+			expectError: nil,
+		},
+		{
+			name:  "OK: Explicit replacement from fallback team",
+			prID:  "pr5",
+			oldID: "old",
+			newID: "chosen",
+			setup: func(prRepo *mocks.PRRepository, userRepo *mocks.UserRepository, teamRepo *mocks.TeamRepository) {
+				prRepo.On("GetByID", mock.Anything, "pr5").Return(&domains.PullRequest{
+					ID:                "pr5",
+					AuthorID:          "author",
+					Status:            domains.PRStatusOpen,
+					AssignedReviewers: []string{"old", "second"},
+				}, nil)
+
+				userRepo.On("GetByID", mock.Anything, "old").Return(&domains.User{ID: "old", TeamName: "Team"}, nil)
+				userRepo.On("GetByID", mock.Anything, "chosen").Return(&domains.User{ID: "chosen", TeamName: "Backup"}, nil)
+				teamRepo.On("GetSettings", mock.Anything, "Team").Return(&domains.TeamSettings{
+					FallbackTeams: []string{"Backup"},
+				}, nil)
+				teamRepo.On("GetSettings", mock.Anything, "Backup").Return(&domains.TeamSettings{}, nil)
+				userRepo.On("GetActiveCandidatesByIDs", mock.Anything, []string{"chosen"}, []string(nil)).
+					Return([]domains.ReviewCandidate{{UserID: "chosen"}}, nil)
+
+				prRepo.On("Update", mock.Anything, mock.MatchedBy(func(pr *domains.PullRequest) bool {
+					return pr.AssignedReviewers[0] == "chosen" && pr.FallbackReviewers[0] == "chosen"
+				})).Return(nil)
+			},
+			expectedID:  "chosen",
+			expectError: nil,
+		},
+		{
+			name:  "Fail: Explicit replacement already assigned",
+			prID:  "pr6",
+			oldID: "old",
+			newID: "second",
+			setup: func(prRepo *mocks.PRRepository, userRepo *mocks.UserRepository, teamRepo *mocks.TeamRepository) {
+				prRepo.On("GetByID", mock.Anything, "pr6").Return(&domains.PullRequest{
+					ID:                "pr6",
+					AuthorID:          "author",
+					Status:            domains.PRStatusOpen,
+					AssignedReviewers: []string{"old", "second"},
+				}, nil)
+
+				userRepo.On("GetByID", mock.Anything, "old").Return(&domains.User{ID: "old", TeamName: "Team"}, nil)
+				teamRepo.On("GetSettings", mock.Anything, "Team").Return(&domains.TeamSettings{}, nil)
+			},
+			expectedID:  "",
+			expectError: service.ErrAlreadyAssigned,
+		},
+		{
+			name:  "Fail: Explicit replacement over review limit",
+			prID:  "pr7",
+			oldID: "old",
+			newID: "busy",
+			setup: func(prRepo *mocks.PRRepository, userRepo *mocks.UserRepository, teamRepo *mocks.TeamRepository) {
+				prRepo.On("GetByID", mock.Anything, "pr7").Return(&domains.PullRequest{
+					ID:                "pr7",
+					AuthorID:          "author",
+					Status:            domains.PRStatusOpen,
+					AssignedReviewers: []string{"old"},
+				}, nil)
+
+				userRepo.On("GetByID", mock.Anything, "old").Return(&domains.User{ID: "old", TeamName: "Team"}, nil)
+				userRepo.On("GetByID", mock.Anything, "busy").Return(&domains.User{ID: "busy", TeamName: "Team"}, nil)
+				limit := 1
+				teamRepo.On("GetSettings", mock.Anything, "Team").Return(&domains.TeamSettings{
+					MaxOpenReviews: &limit,
+				}, nil)
+				userRepo.On("GetActiveCandidatesByIDs", mock.Anything, []string{"busy"}, []string(nil)).
+					Return([]domains.ReviewCandidate{{UserID: "busy", OpenReviews: 1}}, nil)
+			},
+			expectedID:  "",
+			expectError: service.ErrReviewLimitReached,
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			prRepo := mocks.NewPRRepository(t)
 			userRepo := mocks.NewUserRepository(t)
-			tc.setup(prRepo, userRepo)
+			teamRepo := mocks.NewTeamRepository(t)
+			tc.setup(prRepo, userRepo, teamRepo)
 
-			svc := service.NewPRService(prRepo, userRepo)
-			_, newID, err := svc.UpdateReviewer(context.Background(), tc.prID, tc.oldID)
+			svc := newPRService(t, prRepo, userRepo, teamRepo, mocks.NewOwnershipRepository(t), service.AssignmentConfig{})
+			_, newID, err := svc.UpdateReviewer(context.Background(), tc.prID, tc.oldID, tc.newID)
 
 			if tc.expectError != nil {
 				assert.ErrorIs(t, err, tc.expectError)
@@ -184,3 +687,690 @@ func TestPRService_UpdateReviewer(t *testing.T) {
 		})
 	}
 }
+
+func TestPRService_DeactivateUser(t *testing.T) {
+	prRepo := mocks.NewPRRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	teamRepo := mocks.NewTeamRepository(t)
+
+	userRepo.On("GetByID", mock.Anything, "gone").Return(&domains.User{ID: "gone", TeamName: "Team"}, nil)
+	teamRepo.On("GetSettings", mock.Anything, "Team").Return(&domains.TeamSettings{}, nil)
+	prRepo.On("GetOpenByReviewers", mock.Anything, []string{"gone"}).Return([]*domains.PullRequest{
+		{ID: "pr1", AuthorID: "a1", Status: domains.PRStatusOpen, AssignedReviewers: []string{"gone", "x"}},
+		{ID: "pr2", AuthorID: "a2", Status: domains.PRStatusOpen, AssignedReviewers: []string{"gone"}},
+		{ID: "pr3", AuthorID: "a3", Status: domains.PRStatusOpen, AssignedReviewers: []string{"gone", "r1", "r2"}},
+	}, nil)
+
+	userRepo.On("GetActiveCandidatesByTeam", mock.Anything, "Team", []string{"gone"}).
+		Return([]domains.ReviewCandidate{{UserID: "r1"}, {UserID: "r2"}}, nil).Once()
+
+	userRepo.On("DeactivateUsers", mock.Anything, []string{"gone"}, mock.MatchedBy(func(prs []*domains.PullRequest) bool {
+		return len(prs) == 2 &&
+			assert.ObjectsAreEqual([]string{"r1", "x"}, prs[0].AssignedReviewers) &&
+			assert.ObjectsAreEqual([]string{"r2"}, prs[1].AssignedReviewers)
+	})).Return(nil)
+
+	// This seed keeps r1 ahead of r2 in the first shuffle, so pr1 gets r1 and pr2 the less loaded r2.
+	svc := newPRService(t, prRepo, userRepo, teamRepo, mocks.NewOwnershipRepository(t),
+		service.AssignmentConfig{Random: rand.NewPCG(2, 2)})
+	report, err := svc.DeactivateUser(context.Background(), "gone")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domains.Reassignment{
+		{PullRequestID: "pr1", OldUserID: "gone", NewUserID: "r1"},
+		{PullRequestID: "pr2", OldUserID: "gone", NewUserID: "r2"},
+	}, report.Reassigned)
+	assert.Equal(t, []domains.Reassignment{
+		{PullRequestID: "pr3", OldUserID: "gone", Reason: service.ErrNoCandidates.Error()},
+	}, report.Unassigned)
+}
+
+func TestPRService_DeactivateTeam(t *testing.T) {
+	prRepo := mocks.NewPRRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	teamRepo := mocks.NewTeamRepository(t)
+
+	teamRepo.On("GetByName", mock.Anything, "Team").Return(&domains.Team{
+		Name:    "Team",
+		Members: []domains.TeamMember{{UserID: "u1"}, {UserID: "u2"}},
+	}, nil)
+	teamRepo.On("GetSettings", mock.Anything, "Team").
+		Return(&domains.TeamSettings{FallbackTeams: []string{"Backup"}}, nil)
+	teamRepo.On("GetSettings", mock.Anything, "Backup").Return(&domains.TeamSettings{}, nil).Once()
+	prRepo.On("GetOpenByReviewers", mock.Anything, []string{"u1", "u2"}).Return([]*domains.PullRequest{
+		{ID: "pr1", AuthorID: "a1", Status: domains.PRStatusOpen, AssignedReviewers: []string{"u1", "u2"}},
+		{ID: "pr2", AuthorID: "b1", Status: domains.PRStatusOpen, AssignedReviewers: []string{"u2"}},
+	}, nil)
+
+	userRepo.On("GetActiveCandidatesByTeam", mock.Anything, "Backup", []string{"u1", "u2"}).
+		Return([]domains.ReviewCandidate{{UserID: "b1"}, {UserID: "b2"}}, nil).Once()
+
+	userRepo.On("DeactivateTeamMembers", mock.Anything, "Team", mock.MatchedBy(func(prs []*domains.PullRequest) bool {
+		return len(prs) == 2 &&
+			assert.ElementsMatch(t, []string{"b1", "b2"}, prs[0].AssignedReviewers) &&
+			assert.ObjectsAreEqual([]string{"b2"}, prs[1].AssignedReviewers)
+	})).Return(nil)
+
+	svc := newPRService(t, prRepo, userRepo, teamRepo, mocks.NewOwnershipRepository(t), service.AssignmentConfig{})
+	result, err := svc.DeactivateTeam(context.Background(), "Team")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u1", "u2"}, result.DeactivatedUsers)
+	assert.Len(t, result.Reassignments.Reassigned, 3)
+	assert.Empty(t, result.Reassignments.Unassigned)
+}
+
+func TestPRService_DeactivateTeam_NotFound(t *testing.T) {
+	teamRepo := mocks.NewTeamRepository(t)
+	teamRepo.On("GetByName", mock.Anything, "Ghost").Return(nil, nil)
+
+	svc := newPRService(t, mocks.NewPRRepository(t), mocks.NewUserRepository(t), teamRepo,
+		mocks.NewOwnershipRepository(t), service.AssignmentConfig{})
+	_, err := svc.DeactivateTeam(context.Background(), "Ghost")
+
+	assert.ErrorIs(t, err, service.ErrTeamNotFound)
+}
+
+func TestPRService_SubmitReview(t *testing.T) {
+	testCases := []struct {
+		name        string
+		reviewerID  string
+		state       domains.ReviewState
+		pr          *domains.PullRequest
+		expectError error
+	}{
+		{
+			name:       "OK: Approve",
+			reviewerID: "r1",
+			state:      domains.ReviewStateApproved,
+			pr: &domains.PullRequest{ID: "pr1", Status: domains.PRStatusOpen, AssignedReviewers: []string{"r1", "r2"},
+				Reviews: []domains.Review{{ReviewerID: "r1", State: domains.ReviewStatePending}, {ReviewerID: "r2", State: domains.ReviewStatePending}}},
+		},
+		{
+			name:        "Error: Unknown state",
+			reviewerID:  "r1",
+			state:       "LGTM",
+			expectError: service.ErrInvalidReviewState,
+		},
+		{
+			name:        "Error: Dismissed is not a state",
+			reviewerID:  "r1",
+			state:       "DISMISSED",
+			expectError: service.ErrInvalidReviewState,
+		},
+		{
+			name:        "Error: Reviewer cannot reset to pending",
+			reviewerID:  "r1",
+			state:       domains.ReviewStatePending,
+			expectError: service.ErrInvalidReviewState,
+		},
+		{
+			name:        "Error: PR merged",
+			reviewerID:  "r1",
+			state:       domains.ReviewStateApproved,
+			pr:          &domains.PullRequest{ID: "pr1", Status: domains.PRStatusMerged, AssignedReviewers: []string{"r1"}},
+			expectError: service.ErrPRMerged,
+		},
+		{
+			name:        "Error: Not assigned",
+			reviewerID:  "x",
+			state:       domains.ReviewStateChangesRequested,
+			pr:          &domains.PullRequest{ID: "pr1", Status: domains.PRStatusOpen, AssignedReviewers: []string{"r1"}},
+			expectError: service.ErrReviewerNotAssigned,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			prRepo := mocks.NewPRRepository(t)
+			if tc.pr != nil {
+				prRepo.On("GetByID", mock.Anything, "pr1").Return(tc.pr, nil)
+			}
+			if tc.expectError == nil {
+				prRepo.On("SetReviewState", mock.Anything, "pr1", tc.reviewerID, tc.state).Return(nil)
+			}
+
+			svc := newPRService(t, prRepo, mocks.NewUserRepository(t), mocks.NewTeamRepository(t),
+				mocks.NewOwnershipRepository(t), service.AssignmentConfig{})
+			pr, err := svc.SubmitReview(context.Background(), "pr1", tc.reviewerID, tc.state)
+
+			if tc.expectError != nil {
+				assert.ErrorIs(t, err, tc.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.state, pr.Reviews[0].State)
+			assert.NotNil(t, pr.Reviews[0].UpdatedAt)
+			assert.Equal(t, domains.ReviewStatePending, pr.Reviews[1].State)
+		})
+	}
+}
+
+func TestPRService_AddReviewer(t *testing.T) {
+	openPR := func() *domains.PullRequest {
+		return &domains.PullRequest{ID: "pr1", AuthorID: "author", Status: domains.PRStatusOpen,
+			AssignedReviewers: []string{"r1"}}
+	}
+
+	testCases := []struct {
+		name        string
+		reviewerID  string
+		pr          *domains.PullRequest
+		reviewer    *domains.User
+		candidates  []domains.ReviewCandidate
+		expectError error
+	}{
+		{
+			name:       "OK: Teammate added",
+			reviewerID: "mate",
+			pr:         openPR(),
+			reviewer:   &domains.User{ID: "mate", TeamName: "Team", IsActive: true},
+			candidates: []domains.ReviewCandidate{{UserID: "mate"}},
+		},
+		{
+			name:        "Error: PR merged",
+			reviewerID:  "mate",
+			pr:          &domains.PullRequest{ID: "pr1", AuthorID: "author", Status: domains.PRStatusMerged},
+			expectError: service.ErrPRMerged,
+		},
+		{
+			name:        "Error: Author",
+			reviewerID:  "author",
+			pr:          openPR(),
+			expectError: service.ErrReviewerIsAuthor,
+		},
+		{
+			name:        "Error: Already assigned",
+			reviewerID:  "r1",
+			pr:          openPR(),
+			expectError: service.ErrAlreadyAssigned,
+		},
+		{
+			name:        "Error: Other team",
+			reviewerID:  "stranger",
+			pr:          openPR(),
+			reviewer:    &domains.User{ID: "stranger", TeamName: "Other", IsActive: true},
+			expectError: service.ErrNotTeammate,
+		},
+		{
+			name:        "Error: Inactive",
+			reviewerID:  "mate",
+			pr:          openPR(),
+			reviewer:    &domains.User{ID: "mate", TeamName: "Team"},
+			candidates:  []domains.ReviewCandidate{},
+			expectError: service.ErrReviewerUnavailable,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			prRepo := mocks.NewPRRepository(t)
+			userRepo := mocks.NewUserRepository(t)
+			teamRepo := mocks.NewTeamRepository(t)
+
+			prRepo.On("GetByID", mock.Anything, "pr1").Return(tc.pr, nil)
+			if tc.pr.Status.InReview() {
+				userRepo.On("GetByID", mock.Anything, "author").Return(&domains.User{ID: "author", TeamName: "Team"}, nil)
+			}
+			if tc.reviewer != nil {
+				userRepo.On("GetByID", mock.Anything, tc.reviewerID).Return(tc.reviewer, nil)
+			}
+			if tc.candidates != nil {
+				userRepo.On("GetActiveCandidatesByIDs", mock.Anything, []string{tc.reviewerID}, []string(nil)).
+					Return(tc.candidates, nil)
+			}
+			if len(tc.candidates) > 0 {
+				teamRepo.On("GetSettings", mock.Anything, "Team").Return(&domains.TeamSettings{}, nil)
+			}
+			if tc.expectError == nil {
+				prRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+			}
+
+			svc := newPRService(t, prRepo, userRepo, teamRepo, mocks.NewOwnershipRepository(t), service.AssignmentConfig{})
+			pr, err := svc.AddReviewer(context.Background(), "pr1", tc.reviewerID)
+
+			if tc.expectError != nil {
+				assert.ErrorIs(t, err, tc.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []string{"r1", "mate"}, pr.AssignedReviewers)
+			assert.Equal(t, domains.ReviewStatePending, pr.Reviews[len(pr.Reviews)-1].State)
+		})
+	}
+}
+
+func TestPRService_RemoveReviewer(t *testing.T) {
+	testCases := []struct {
+		name        string
+		reviewerID  string
+		status      domains.PRStatus
+		required    int
+		expectError error
+	}{
+		{
+			name:       "OK: Above minimum",
+			reviewerID: "r2",
+			status:     domains.PRStatusOpen,
+			required:   1,
+		},
+		{
+			name:        "Error: Below minimum",
+			reviewerID:  "r2",
+			status:      domains.PRStatusOpen,
+			required:    2,
+			expectError: service.ErrMinReviewers,
+		},
+		{
+			name:        "Error: Not assigned",
+			reviewerID:  "x",
+			status:      domains.PRStatusOpen,
+			expectError: service.ErrReviewerNotAssigned,
+		},
+		{
+			name:        "Error: PR merged",
+			reviewerID:  "r2",
+			status:      domains.PRStatusMerged,
+			expectError: service.ErrPRMerged,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			prRepo := mocks.NewPRRepository(t)
+			userRepo := mocks.NewUserRepository(t)
+			teamRepo := mocks.NewTeamRepository(t)
+
+			prRepo.On("GetByID", mock.Anything, "pr1").Return(&domains.PullRequest{
+				ID: "pr1", AuthorID: "author", Status: tc.status,
+				AssignedReviewers: []string{"r1", "r2"},
+				Reviews: []domains.Review{
+					{ReviewerID: "r1", State: domains.ReviewStatePending},
+					{ReviewerID: "r2", State: domains.ReviewStatePending},
+				},
+			}, nil)
+			if tc.required > 0 {
+				userRepo.On("GetByID", mock.Anything, "author").Return(&domains.User{ID: "author", TeamName: "Team"}, nil)
+				teamRepo.On("GetSettings", mock.Anything, "Team").
+					Return(&domains.TeamSettings{RequiredReviewers: tc.required}, nil)
+			}
+			if tc.expectError == nil {
+				prRepo.On("Update", mock.Anything, mock.MatchedBy(func(pr *domains.PullRequest) bool {
+					return pr.ChangeReason == domains.ReplacementRemoved
+				})).Return(nil)
+			}
+
+			svc := newPRService(t, prRepo, userRepo, teamRepo, mocks.NewOwnershipRepository(t), service.AssignmentConfig{})
+			pr, err := svc.RemoveReviewer(context.Background(), "pr1", tc.reviewerID)
+
+			if tc.expectError != nil {
+				assert.ErrorIs(t, err, tc.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []string{"r1"}, pr.AssignedReviewers)
+			assert.Len(t, pr.Reviews, 1)
+		})
+	}
+}
+
+func TestPRService_GetHistory(t *testing.T) {
+	prRepo := mocks.NewPRRepository(t)
+	prRepo.On("Exists", mock.Anything, "pr1").Return(true, nil)
+	prRepo.On("Exists", mock.Anything, "ghost").Return(false, nil)
+	prRepo.On("GetEvents", mock.Anything, "pr1").Return([]domains.PREvent{
+		{ID: 1, PullRequestID: "pr1", Type: domains.PREventCreated},
+		{ID: 2, PullRequestID: "pr1", Type: domains.PREventReviewerReplaced, ReviewerID: "u1", ReplacedBy: "u2",
+			Reason: domains.ReplacementReassigned},
+	}, nil)
+
+	svc := newPRService(t, prRepo, mocks.NewUserRepository(t), mocks.NewTeamRepository(t),
+		mocks.NewOwnershipRepository(t), service.AssignmentConfig{})
+
+	events, err := svc.GetHistory(context.Background(), "pr1")
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+
+	_, err = svc.GetHistory(context.Background(), "ghost")
+	assert.ErrorIs(t, err, service.ErrPRNotFound)
+}
+
+func TestPRService_StatusTransitions(t *testing.T) {
+	testCases := []struct {
+		name        string
+		status      domains.PRStatus
+		action      func(svc service.PRService) (*domains.PullRequest, error)
+		setup       func(prRepo *mocks.PRRepository, userRepo *mocks.UserRepository, teamRepo *mocks.TeamRepository)
+		expectError error
+		expected    domains.PRStatus
+	}{
+		{
+			name:   "OK: Close open PR releases reviewers",
+			status: domains.PRStatusOpen,
+			action: func(svc service.PRService) (*domains.PullRequest, error) {
+				return svc.ClosePR(context.Background(), "pr1")
+			},
+			setup: func(prRepo *mocks.PRRepository, userRepo *mocks.UserRepository, teamRepo *mocks.TeamRepository) {
+				prRepo.On("Update", mock.Anything, mock.MatchedBy(func(pr *domains.PullRequest) bool {
+					return pr.Status == domains.PRStatusClosed && len(pr.AssignedReviewers) == 0
+				})).Return(nil)
+			},
+			expected: domains.PRStatusClosed,
+		},
+		{
+			name:   "Error: Close merged PR",
+			status: domains.PRStatusMerged,
+			action: func(svc service.PRService) (*domains.PullRequest, error) {
+				return svc.ClosePR(context.Background(), "pr1")
+			},
+			expectError: service.ErrPRMerged,
+		},
+		{
+			name:   "OK: Reopen closed PR assigns reviewers",
+			status: domains.PRStatusClosed,
+			action: func(svc service.PRService) (*domains.PullRequest, error) {
+				return svc.ReopenPR(context.Background(), "pr1")
+			},
+			setup: func(prRepo *mocks.PRRepository, userRepo *mocks.UserRepository, teamRepo *mocks.TeamRepository) {
+				userRepo.On("GetByID", mock.Anything, "a1").Return(&domains.User{ID: "a1", TeamName: "Team"}, nil)
+				teamRepo.On("GetSettings", mock.Anything, "Team").Return(&domains.TeamSettings{}, nil)
+				userRepo.On("GetActiveCandidatesByTeam", mock.Anything, "Team", []string{"a1"}).
+					Return([]domains.ReviewCandidate{{UserID: "r1"}, {UserID: "r2"}}, nil)
+				prRepo.On("Update", mock.Anything, mock.MatchedBy(func(pr *domains.PullRequest) bool {
+					return pr.Status == domains.PRStatusReopened && len(pr.AssignedReviewers) == 2
+				})).Return(nil)
+			},
+			expected: domains.PRStatusReopened,
+		},
+		{
+			name:   "Error: Reopen open PR",
+			status: domains.PRStatusOpen,
+			action: func(svc service.PRService) (*domains.PullRequest, error) {
+				return svc.ReopenPR(context.Background(), "pr1")
+			},
+			expectError: service.ErrInvalidTransition,
+		},
+		{
+			name:   "Error: Merge closed PR",
+			status: domains.PRStatusClosed,
+			action: func(svc service.PRService) (*domains.PullRequest, error) {
+				return svc.MergePR(context.Background(), "pr1")
+			},
+			expectError: service.ErrPRClosed,
+		},
+		{
+			name:   "Error: Merge draft PR",
+			status: domains.PRStatusDraft,
+			action: func(svc service.PRService) (*domains.PullRequest, error) {
+				return svc.MergePR(context.Background(), "pr1")
+			},
+			expectError: service.ErrPRDraft,
+		},
+		{
+			name:   "OK: Close draft PR",
+			status: domains.PRStatusDraft,
+			action: func(svc service.PRService) (*domains.PullRequest, error) {
+				return svc.ClosePR(context.Background(), "pr1")
+			},
+			setup: func(prRepo *mocks.PRRepository, userRepo *mocks.UserRepository, teamRepo *mocks.TeamRepository) {
+				prRepo.On("Update", mock.Anything, mock.MatchedBy(func(pr *domains.PullRequest) bool {
+					return pr.Status == domains.PRStatusClosed
+				})).Return(nil)
+			},
+			expected: domains.PRStatusClosed,
+		},
+		{
+			name:   "Error: Reopen draft PR",
+			status: domains.PRStatusDraft,
+			action: func(svc service.PRService) (*domains.PullRequest, error) {
+				return svc.ReopenPR(context.Background(), "pr1")
+			},
+			expectError: service.ErrPRDraft,
+		},
+		{
+			name:   "Error: Review draft PR",
+			status: domains.PRStatusDraft,
+			action: func(svc service.PRService) (*domains.PullRequest, error) {
+				return svc.SubmitReview(context.Background(), "pr1", "r1", domains.ReviewStateApproved)
+			},
+			expectError: service.ErrPRDraft,
+		},
+		{
+			name:   "Error: Mark merged PR ready",
+			status: domains.PRStatusMerged,
+			action: func(svc service.PRService) (*domains.PullRequest, error) {
+				return svc.MarkReady(context.Background(), "pr1", nil)
+			},
+			expectError: service.ErrPRMerged,
+		},
+		{
+			name:   "Error: Mark closed PR ready",
+			status: domains.PRStatusClosed,
+			action: func(svc service.PRService) (*domains.PullRequest, error) {
+				return svc.MarkReady(context.Background(), "pr1", nil)
+			},
+			expectError: service.ErrPRClosed,
+		},
+		{
+			name:   "Error: Review closed PR",
+			status: domains.PRStatusClosed,
+			action: func(svc service.PRService) (*domains.PullRequest, error) {
+				return svc.SubmitReview(context.Background(), "pr1", "r1", domains.ReviewStateApproved)
+			},
+			expectError: service.ErrPRClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			prRepo := mocks.NewPRRepository(t)
+			userRepo := mocks.NewUserRepository(t)
+			teamRepo := mocks.NewTeamRepository(t)
+			prRepo.On("GetByID", mock.Anything, "pr1").Return(&domains.PullRequest{
+				ID: "pr1", AuthorID: "a1", Status: tc.status, AssignedReviewers: []string{"r1"},
+			}, nil)
+			if tc.setup != nil {
+				tc.setup(prRepo, userRepo, teamRepo)
+			}
+
+			svc := newPRService(t, prRepo, userRepo, teamRepo, mocks.NewOwnershipRepository(t), service.AssignmentConfig{})
+			pr, err := tc.action(svc)
+
+			if tc.expectError != nil {
+				assert.ErrorIs(t, err, tc.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, pr.Status)
+		})
+	}
+}
+
+func TestPRService_DraftLifecycle(t *testing.T) {
+	prRepo := mocks.NewPRRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	teamRepo := mocks.NewTeamRepository(t)
+
+	userRepo.On("GetByID", mock.Anything, "a1").Return(&domains.User{ID: "a1", TeamName: "Team", IsActive: true}, nil)
+	prRepo.On("Create", mock.Anything, mock.MatchedBy(func(pr *domains.PullRequest) bool {
+		return pr.Status == domains.PRStatusDraft && len(pr.AssignedReviewers) == 0
+	})).Return(nil)
+
+	svc := newPRService(t, prRepo, userRepo, teamRepo, mocks.NewOwnershipRepository(t), service.AssignmentConfig{})
+	draft, err := svc.CreatePR(context.Background(), domains.PullRequestInput{
+		ID: "pr1", Name: "WIP", AuthorID: "a1", Draft: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domains.PRStatusDraft, draft.Status)
+
+	prRepo.On("GetByID", mock.Anything, "pr1").Return(draft, nil)
+	teamRepo.On("GetSettings", mock.Anything, "Team").Return(&domains.TeamSettings{}, nil)
+	userRepo.On("GetActiveCandidatesByTeam", mock.Anything, "Team", []string{"a1"}).
+		Return([]domains.ReviewCandidate{{UserID: "r1"}, {UserID: "r2"}}, nil)
+	prRepo.On("Update", mock.Anything, mock.MatchedBy(func(pr *domains.PullRequest) bool {
+		return pr.Status == domains.PRStatusOpen
+	})).Return(nil)
+
+	ready, err := svc.MarkReady(context.Background(), "pr1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, domains.PRStatusOpen, ready.Status)
+	assert.ElementsMatch(t, []string{"r1", "r2"}, ready.AssignedReviewers)
+
+	_, err = svc.MarkReady(context.Background(), "pr1", nil)
+	assert.ErrorIs(t, err, service.ErrInvalidTransition)
+}
+
+func TestPRService_MarkOverdueReviews(t *testing.T) {
+	friday := time.Date(2025, time.March, 7, 12, 0, 0, 0, time.UTC)
+	monday := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	flagged := friday.Add(time.Hour)
+
+	prRepo := mocks.NewPRRepository(t)
+	prRepo.On("GetPendingReviews", mock.Anything).Return([]domains.PendingReview{
+		{PullRequestID: "pr1", ReviewerID: "late", AssignedAt: friday, SLAHours: 9},
+		{PullRequestID: "pr2", ReviewerID: "weekend", AssignedAt: friday, SLAHours: 10},
+		{PullRequestID: "pr3", ReviewerID: "flagged", AssignedAt: friday, SLAHours: 1, OverdueAt: &flagged},
+	}, nil)
+	prRepo.On("MarkOverdue", mock.Anything, mock.MatchedBy(func(reviews []domains.PendingReview) bool {
+		return len(reviews) == 1 && reviews[0].ReviewerID == "late"
+	}), monday).Return(nil)
+
+	svc := newPRService(t, prRepo, mocks.NewUserRepository(t), mocks.NewTeamRepository(t),
+		mocks.NewOwnershipRepository(t), service.AssignmentConfig{})
+	marked, err := svc.MarkOverdueReviews(context.Background(), monday)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, marked)
+}
+
+func TestPRService_GetOverdueReviews(t *testing.T) {
+	assigned := time.Now().Add(-2 * time.Hour)
+	flagged := time.Now()
+
+	prRepo := mocks.NewPRRepository(t)
+	prRepo.On("GetOverdueReviews", mock.Anything, "backend").Return([]domains.PendingReview{
+		{PullRequestID: "pr1", ReviewerID: "r1", TeamName: "backend", AssignedAt: assigned, OverdueAt: &flagged},
+	}, nil)
+	teamRepo := mocks.NewTeamRepository(t)
+	teamRepo.On("Exists", mock.Anything, "backend").Return(true, nil)
+	teamRepo.On("Exists", mock.Anything, "ghost").Return(false, nil)
+
+	svc := newPRService(t, prRepo, mocks.NewUserRepository(t), teamRepo,
+		mocks.NewOwnershipRepository(t), service.AssignmentConfig{})
+	reviews, err := svc.GetOverdueReviews(context.Background(), "backend")
+
+	require.NoError(t, err)
+	require.Len(t, reviews, 1)
+	assert.Equal(t, "r1", reviews[0].ReviewerID)
+	assert.LessOrEqual(t, reviews[0].AgeHours, 2.1)
+
+	_, err = svc.GetOverdueReviews(context.Background(), "ghost")
+	assert.ErrorIs(t, err, service.ErrTeamNotFound)
+}
+
+func TestPRService_SLACountsBusinessHours(t *testing.T) {
+	// Friday 16:00 to Monday 10:00 UTC is 2 working hours on Friday and 1 on Monday
+	// with the default 09:00-18:00 UTC day. In UTC+3 the same span is 19:00 to 13:00:
+	// nothing on Friday and 4 hours on Monday.
+	friday := time.Date(2025, time.March, 7, 16, 0, 0, 0, time.UTC)
+	monday := time.Date(2025, time.March, 10, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name         string
+		workingHours service.WorkingHours
+		overdue      []string
+	}{
+		{name: "Default 09:00-18:00 UTC", overdue: []string{"three"}},
+		{
+			name:         "Configured zone",
+			workingHours: service.WorkingHours{StartHour: 9, EndHour: 18, Location: time.FixedZone("UTC+3", 3*60*60)},
+			overdue:      []string{"three", "four"},
+		},
+		{
+			name:         "Configured hours",
+			workingHours: service.WorkingHours{StartHour: 8, EndHour: 16},
+			overdue:      []string{"three"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			prRepo := mocks.NewPRRepository(t)
+			prRepo.On("GetPendingReviews", mock.Anything).Return([]domains.PendingReview{
+				{PullRequestID: "pr1", ReviewerID: "three", AssignedAt: friday, SLAHours: 2},
+				{PullRequestID: "pr2", ReviewerID: "four", AssignedAt: friday, SLAHours: 4},
+				{PullRequestID: "pr3", ReviewerID: "five", AssignedAt: friday, SLAHours: 5},
+			}, nil)
+			prRepo.On("MarkOverdue", mock.Anything, mock.MatchedBy(func(reviews []domains.PendingReview) bool {
+				ids := make([]string, 0, len(reviews))
+				for _, review := range reviews {
+					ids = append(ids, review.ReviewerID)
+				}
+				return slices.Equal(ids, tc.overdue)
+			}), monday).Return(nil)
+
+			svc := newPRService(t, prRepo, mocks.NewUserRepository(t), mocks.NewTeamRepository(t),
+				mocks.NewOwnershipRepository(t), service.AssignmentConfig{WorkingHours: tc.workingHours})
+			marked, err := svc.MarkOverdueReviews(context.Background(), monday)
+
+			require.NoError(t, err)
+			assert.Equal(t, len(tc.overdue), marked)
+		})
+	}
+}
+
+func TestPRService_EscalateStaleReviews(t *testing.T) {
+	now := time.Date(2025, time.March, 12, 12, 0, 0, 0, time.UTC)
+
+	prRepo := mocks.NewPRRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	teamRepo := mocks.NewTeamRepository(t)
+
+	prRepo.On("GetPendingReviews", mock.Anything).Return([]domains.PendingReview{
+		{PullRequestID: "pr1", ReviewerID: "stale", AssignedAt: now.Add(-24 * time.Hour), EscalationHours: 8},
+		{PullRequestID: "pr2", ReviewerID: "fresh", AssignedAt: now.Add(-time.Hour), EscalationHours: 8},
+		{PullRequestID: "pr3", ReviewerID: "optout", AssignedAt: now.Add(-100 * time.Hour)},
+	}, nil)
+	prRepo.On("GetTimedOutReviewers", mock.Anything, "pr1").Return([]string{"earlier"}, nil)
+	prRepo.On("GetByID", mock.Anything, "pr1").Return(&domains.PullRequest{
+		ID:                "pr1",
+		AuthorID:          "author",
+		Status:            domains.PRStatusOpen,
+		AssignedReviewers: []string{"stale", "other"},
+	}, nil)
+	userRepo.On("GetByID", mock.Anything, "stale").Return(&domains.User{ID: "stale", TeamName: "Team"}, nil)
+	teamRepo.On("GetSettings", mock.Anything, "Team").Return(&domains.TeamSettings{}, nil)
+	userRepo.On("GetActiveCandidatesByTeam", mock.Anything, "Team", []string{"author", "stale", "other", "earlier"}).
+		Return([]domains.ReviewCandidate{{UserID: "next"}}, nil)
+	prRepo.On("Update", mock.Anything, mock.MatchedBy(func(pr *domains.PullRequest) bool {
+		return pr.AssignedReviewers[0] == "next" && pr.ChangeReason == domains.ReplacementSLATimeout
+	})).Return(nil)
+
+	svc := newPRService(t, prRepo, userRepo, teamRepo, mocks.NewOwnershipRepository(t), service.AssignmentConfig{})
+	escalated, err := svc.EscalateStaleReviews(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, escalated)
+}
+
+func TestPRService_CreatePR_Duplicate(t *testing.T) {
+	prRepo := mocks.NewPRRepository(t)
+	userRepo := mocks.NewUserRepository(t)
+	userRepo.On("GetByID", mock.Anything, "u1").Return(&domains.User{ID: "u1", TeamName: "Team"}, nil)
+	prRepo.On("Create", mock.Anything, mock.Anything).Return(repository.ErrAlreadyExists)
+
+	svc := newPRService(t, prRepo, userRepo, mocks.NewTeamRepository(t), mocks.NewOwnershipRepository(t),
+		service.AssignmentConfig{})
+	_, err := svc.CreatePR(context.Background(), domains.PullRequestInput{ID: "pr1", AuthorID: "u1", Draft: true})
+
+	assert.ErrorIs(t, err, service.ErrPRExists)
+}
+
+func TestWorkingHours_Validate(t *testing.T) {
+	assert.NoError(t, service.WorkingHours{StartHour: 9, EndHour: 18}.Validate())
+	assert.NoError(t, service.WorkingHours{StartHour: 0, EndHour: 24}.Validate())
+	assert.ErrorIs(t, service.WorkingHours{StartHour: 18, EndHour: 9}.Validate(), service.ErrInvalidWorkingHours)
+	assert.ErrorIs(t, service.WorkingHours{StartHour: 9, EndHour: 9}.Validate(), service.ErrInvalidWorkingHours)
+	assert.ErrorIs(t, service.WorkingHours{StartHour: -1, EndHour: 18}.Validate(), service.ErrInvalidWorkingHours)
+	assert.ErrorIs(t, service.WorkingHours{StartHour: 9, EndHour: 25}.Validate(), service.ErrInvalidWorkingHours)
+}
@@ -0,0 +1,550 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ReviewerAssignmentService/internal/domains"
+	"ReviewerAssignmentService/internal/repository"
+	"ReviewerAssignmentService/internal/repository/memory"
+	internalPostgres "ReviewerAssignmentService/internal/repository/postgres"
+)
+
+// contractRepos are the repositories of one storage backend.
+type contractRepos struct {
+	repository.Repositories
+	UnitOfWork  repository.UnitOfWork
+	Webhook     repository.WebhookRepository
+	Idempotency repository.IdempotencyRepository
+}
+
+type repositoryBackend struct {
+	name string
+	open func(t *testing.T) contractRepos
+}
+
+var repositoryBackends = []repositoryBackend{
+	{
+		name: "memory",
+		open: func(t *testing.T) contractRepos {
+			store := memory.NewStore()
+			return contractRepos{
+				Repositories: repository.Repositories{
+					PR:        memory.NewPrRepository(store),
+					User:      memory.NewUserRepository(store),
+					Team:      memory.NewTeamRepository(store),
+					Ownership: memory.NewOwnershipRepository(store),
+				},
+				UnitOfWork:  memory.NewUnitOfWork(store),
+				Webhook:     memory.NewWebhookRepository(store),
+				Idempotency: memory.NewIdempotencyRepository(store),
+			}
+		},
+	},
+	{
+		name: "postgres",
+		open: func(t *testing.T) contractRepos {
+			pool := setupDB(t)
+			t.Cleanup(pool.Close)
+			return contractRepos{
+				Repositories: repository.Repositories{
+					PR:        internalPostgres.NewPrRepository(pool),
+					User:      internalPostgres.NewUserRepository(pool),
+					Team:      internalPostgres.NewTeamRepository(pool),
+					Ownership: internalPostgres.NewOwnershipRepository(pool),
+				},
+				UnitOfWork:  internalPostgres.NewUnitOfWork(pool),
+				Webhook:     internalPostgres.NewWebhookRepository(pool),
+				Idempotency: internalPostgres.NewIdempotencyRepository(pool),
+			}
+		},
+	},
+}
+
+// runContract runs test against every storage backend, so that the in-memory
+// repositories keep behaving like the Postgres ones.
+func runContract(t *testing.T, test func(t *testing.T, repos contractRepos)) {
+	for _, backend := range repositoryBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			test(t, backend.open(t))
+		})
+	}
+}
+
+// seedTeam creates a team whose members are all active.
+func seedTeam(t *testing.T, repos contractRepos, team *domains.Team, userIDs ...string) {
+	for _, id := range userIDs {
+		team.Members = append(team.Members, domains.TeamMember{UserID: id, UserName: "User " + id, IsActive: true})
+	}
+	require.NoError(t, repos.Team.Create(context.Background(), team))
+}
+
+func seedPR(t *testing.T, repos contractRepos, id string, authorID string, reviewers ...string) *domains.PullRequest {
+	ctx := context.Background()
+	require.NoError(t, repos.PR.Create(ctx, &domains.PullRequest{
+		ID:                id,
+		Name:              "PR " + id,
+		AuthorID:          authorID,
+		Status:            domains.PRStatusOpen,
+		AssignedReviewers: reviewers,
+	}))
+	pr, err := repos.PR.GetByID(ctx, id)
+	require.NoError(t, err)
+	return pr
+}
+
+func eventTypesOf(events []domains.PREvent) []domains.PREventType {
+	types := make([]domains.PREventType, 0, len(events))
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+func TestRepositoryContract_TeamsAndUsers(t *testing.T) {
+	runContract(t, func(t *testing.T, repos contractRepos) {
+		ctx := context.Background()
+		limit := 3
+		seedTeam(t, repos, &domains.Team{Name: "Backup"}, "b1")
+		seedTeam(t, repos, &domains.Team{
+			Name: "Core",
+			TeamSettings: domains.TeamSettings{
+				ReviewerStrategy: "round_robin",
+				MaxOpenReviews:   &limit,
+				FallbackTeams:    []string{"Backup", "Missing"},
+				ReviewSLAHours:   8,
+			},
+		}, "c1", "c2")
+
+		team, err := repos.Team.GetByName(ctx, "Core")
+		require.NoError(t, err)
+		require.NotNil(t, team)
+		assert.ElementsMatch(t, []domains.TeamMember{
+			{UserID: "c1", UserName: "User c1", IsActive: true},
+			{UserID: "c2", UserName: "User c2", IsActive: true},
+		}, team.Members)
+		assert.Equal(t, "round_robin", team.ReviewerStrategy)
+		assert.Equal(t, []string{"Backup"}, team.FallbackTeams)
+		require.NotNil(t, team.MaxOpenReviews)
+		assert.Equal(t, 3, *team.MaxOpenReviews)
+
+		settings, err := repos.Team.GetSettings(ctx, "Core")
+		require.NoError(t, err)
+		assert.Equal(t, 8, settings.ReviewSLAHours)
+
+		missing, err := repos.Team.GetByName(ctx, "Nobody")
+		require.NoError(t, err)
+		assert.Nil(t, missing)
+		exists, err := repos.Team.Exists(ctx, "Nobody")
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		require.NoError(t, repos.User.Create(ctx, &domains.User{ID: "c1", Name: "Renamed", TeamName: "Core"}))
+		user, err := repos.User.GetByID(ctx, "c1")
+		require.NoError(t, err)
+		assert.Equal(t, &domains.User{ID: "c1", Name: "User c1", TeamName: "Core", IsActive: false}, user,
+			"creating an existing user only updates its activity")
+
+		require.NoError(t, repos.User.UpdateActivity(ctx, "c1", true))
+		assert.Error(t, repos.User.UpdateActivity(ctx, "ghost", true))
+
+		user, err = repos.User.GetByID(ctx, "ghost")
+		require.NoError(t, err)
+		assert.Nil(t, user)
+
+		count, err := repos.User.Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+
+		candidates, err := repos.User.GetActiveCandidatesByTeam(ctx, "Core", []string{"c1"})
+		require.NoError(t, err)
+		require.Len(t, candidates, 1)
+		assert.Equal(t, "c2", candidates[0].UserID)
+	})
+}
+
+func TestRepositoryContract_CandidatesSkipUnavailableAndCountOpenReviews(t *testing.T) {
+	runContract(t, func(t *testing.T, repos contractRepos) {
+		ctx := context.Background()
+		seedTeam(t, repos, &domains.Team{Name: "Team"}, "author", "busy", "free", "away", "off")
+		require.NoError(t, repos.User.UpdateActivity(ctx, "off", false))
+
+		now := time.Now()
+		away := &domains.Unavailability{
+			UserID: "away", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour), Reason: domains.UnavailabilityVacation,
+		}
+		require.NoError(t, repos.User.AddUnavailability(ctx, away))
+		require.NoError(t, repos.User.AddUnavailability(ctx, &domains.Unavailability{
+			UserID: "free", StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour), Reason: domains.UnavailabilityOther,
+		}))
+		assert.NotZero(t, away.ID)
+
+		seedPR(t, repos, "pr-1", "author", "busy")
+		seedPR(t, repos, "pr-2", "author", "busy")
+
+		candidates, err := repos.User.GetActiveCandidatesByTeam(ctx, "Team", []string{"author"})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []domains.ReviewCandidate{
+			{UserID: "busy", OpenReviews: 2},
+			{UserID: "free", OpenReviews: 0},
+		}, candidates)
+
+		candidates, err = repos.User.GetActiveCandidatesByIDs(ctx, []string{"busy", "away", "off"}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []domains.ReviewCandidate{{UserID: "busy", OpenReviews: 2}}, candidates)
+
+		deleted, err := repos.User.DeleteUnavailability(ctx, away.ID)
+		require.NoError(t, err)
+		assert.True(t, deleted)
+		deleted, err = repos.User.DeleteUnavailability(ctx, away.ID)
+		require.NoError(t, err)
+		assert.False(t, deleted)
+
+		periods, err := repos.User.GetUnavailability(ctx, "away")
+		require.NoError(t, err)
+		assert.Empty(t, periods)
+		assert.NotNil(t, periods)
+	})
+}
+
+func TestRepositoryContract_PullRequestLifecycle(t *testing.T) {
+	runContract(t, func(t *testing.T, repos contractRepos) {
+		ctx := domains.WithActor(context.Background(), "lead")
+		seedTeam(t, repos, &domains.Team{Name: "Team"}, "author", "r1", "r2", "r3")
+
+		pr := seedPR(t, repos, "pr-life", "author", "r1", "r2")
+		assert.Equal(t, []string{"r1", "r2"}, pr.AssignedReviewers)
+		assert.Empty(t, pr.OwnerReviewers)
+		require.Len(t, pr.Reviews, 2)
+		assert.Equal(t, domains.ReviewStatePending, pr.Reviews[0].State)
+		assert.Nil(t, pr.MergedAt)
+
+		assert.ErrorIs(t, repos.PR.Create(ctx, &domains.PullRequest{
+			ID: "pr-life", Name: "Again", AuthorID: "author", Status: domains.PRStatusOpen,
+		}), repository.ErrAlreadyExists)
+
+		require.NoError(t, repos.PR.SetReviewState(ctx, "pr-life", "r1", domains.ReviewStateApproved))
+		assert.ErrorIs(t, repos.PR.SetReviewState(ctx, "pr-life", "r3", domains.ReviewStateApproved),
+			repository.ErrConflict, "only an assigned reviewer can submit a review")
+
+		pr, err := repos.PR.GetByID(ctx, "pr-life")
+		require.NoError(t, err)
+		assert.Empty(t, pr.ChangedFiles)
+		pr.AssignedReviewers = []string{"r1", "r3"}
+		pr.OwnerReviewers = []string{"r3"}
+		pr.ChangedFiles = []string{"api/handler.go"}
+		require.NoError(t, repos.PR.Update(ctx, pr))
+
+		short, err := repos.PR.GetByReviewer(ctx, "r1")
+		require.NoError(t, err)
+		require.Len(t, short, 1)
+		assert.Equal(t, domains.ReviewStateApproved, short[0].ReviewState)
+		short, err = repos.PR.GetByReviewer(ctx, "r2")
+		require.NoError(t, err)
+		assert.Empty(t, short)
+
+		open, err := repos.PR.GetOpenByReviewers(ctx, []string{"r3"})
+		require.NoError(t, err)
+		require.Len(t, open, 1)
+		assert.Equal(t, []string{"r3"}, open[0].OwnerReviewers)
+
+		pr.Status = domains.PRStatusMerged
+		require.NoError(t, repos.PR.Update(ctx, pr))
+
+		merged, err := repos.PR.GetByID(ctx, "pr-life")
+		require.NoError(t, err)
+		assert.Equal(t, domains.PRStatusMerged, merged.Status)
+		assert.NotNil(t, merged.MergedAt)
+		assert.Equal(t, []string{"api/handler.go"}, merged.ChangedFiles)
+		assert.Equal(t, pr.Version, merged.Version)
+
+		open, err = repos.PR.GetOpenByReviewers(ctx, []string{"r3"})
+		require.NoError(t, err)
+		assert.Empty(t, open)
+
+		events, err := repos.PR.GetEvents(ctx, "pr-life")
+		require.NoError(t, err)
+		assert.Equal(t, []domains.PREventType{
+			domains.PREventCreated,
+			domains.PREventReviewerAssigned,
+			domains.PREventReviewerAssigned,
+			domains.PREventReviewSubmitted,
+			domains.PREventReviewerReplaced,
+			domains.PREventMerged,
+		}, eventTypesOf(events))
+		assert.Equal(t, "r1", events[3].Actor)
+		assert.Equal(t, domains.PREvent{
+			ID:            events[4].ID,
+			PullRequestID: "pr-life",
+			Type:          domains.PREventReviewerReplaced,
+			Actor:         "lead",
+			ReviewerID:    "r2",
+			ReplacedBy:    "r3",
+			Reason:        domains.ReplacementReassigned,
+			CreatedAt:     events[4].CreatedAt,
+		}, events[4])
+
+		count, err := repos.PR.Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+}
+
+func TestRepositoryContract_StaleWritesConflict(t *testing.T) {
+	runContract(t, func(t *testing.T, repos contractRepos) {
+		ctx := context.Background()
+		seedTeam(t, repos, &domains.Team{Name: "Team"}, "author", "r1", "r2", "r3")
+
+		first := seedPR(t, repos, "pr-stale", "author", "r1", "r2")
+		second, err := repos.PR.GetByID(ctx, "pr-stale")
+		require.NoError(t, err)
+
+		first.AssignedReviewers = []string{"r3", "r2"}
+		require.NoError(t, repos.PR.Update(ctx, first))
+
+		second.AssignedReviewers = []string{"r1", "r3"}
+		assert.ErrorIs(t, repos.PR.Update(ctx, second), repository.ErrConflict)
+		assert.ErrorIs(t, repos.PR.MergeWithOverride(ctx, second, &domains.MergeOverride{
+			PullRequestID: "pr-stale", ForcedBy: "lead",
+		}), repository.ErrConflict)
+		assert.ErrorIs(t, repos.User.DeactivateUsers(ctx, []string{"r2"}, []*domains.PullRequest{second}),
+			repository.ErrConflict)
+
+		fetched, err := repos.PR.GetByID(ctx, "pr-stale")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"r3", "r2"}, fetched.AssignedReviewers)
+		assert.Equal(t, first.Version, fetched.Version)
+
+		user, err := repos.User.GetByID(ctx, "r2")
+		require.NoError(t, err)
+		assert.True(t, user.IsActive, "a rejected deactivation must leave no trace")
+
+		require.NoError(t, repos.PR.MergeWithOverride(ctx, fetched, &domains.MergeOverride{
+			PullRequestID: "pr-stale", ForcedBy: "lead", Reason: "hotfix",
+		}))
+		merged, err := repos.PR.GetByID(ctx, "pr-stale")
+		require.NoError(t, err)
+		assert.Equal(t, domains.PRStatusMerged, merged.Status)
+		assert.Equal(t, fetched.Version, merged.Version)
+	})
+}
+
+func TestRepositoryContract_ConcurrentUpdatesConflict(t *testing.T) {
+	runContract(t, func(t *testing.T, repos contractRepos) {
+		ctx := context.Background()
+		seedTeam(t, repos, &domains.Team{Name: "Team"}, "author", "r1", "r2", "r3", "r4")
+		seedPR(t, repos, "pr-race", "author", "r1", "r2")
+
+		var wg sync.WaitGroup
+		errs := make([]error, 2)
+		for i, replacement := range []string{"r3", "r4"} {
+			pr, err := repos.PR.GetByID(ctx, "pr-race")
+			require.NoError(t, err)
+			pr.AssignedReviewers = []string{replacement, "r2"}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = repos.PR.Update(ctx, pr)
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, 1, countNil(errs), "exactly one of the writers must win: %v", errs)
+		fetched, err := repos.PR.GetByID(ctx, "pr-race")
+		require.NoError(t, err)
+		assert.Len(t, fetched.AssignedReviewers, 2)
+	})
+}
+
+func TestRepositoryContract_ReviewSLA(t *testing.T) {
+	runContract(t, func(t *testing.T, repos contractRepos) {
+		ctx := context.Background()
+		seedTeam(t, repos, &domains.Team{Name: "Slow"}, "slow_author", "s1")
+		seedTeam(t, repos, &domains.Team{
+			Name:         "Team",
+			TeamSettings: domains.TeamSettings{ReviewSLAHours: 4, EscalationHours: 8},
+		}, "author", "r1", "r2", "r3")
+
+		seedPR(t, repos, "pr-no-sla", "slow_author", "s1")
+		pr := seedPR(t, repos, "pr-sla", "author", "r1", "r2")
+		require.NoError(t, repos.PR.SetReviewState(ctx, "pr-sla", "r2", domains.ReviewStateApproved))
+
+		pending, err := repos.PR.GetPendingReviews(ctx)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, "r1", pending[0].ReviewerID)
+		assert.Equal(t, "Team", pending[0].TeamName)
+		assert.Equal(t, 4, pending[0].SLAHours)
+		assert.Equal(t, 8, pending[0].EscalationHours)
+		assert.Nil(t, pending[0].OverdueAt)
+
+		at := time.Now()
+		require.NoError(t, repos.PR.MarkOverdue(ctx, pending, at))
+		require.NoError(t, repos.PR.MarkOverdue(ctx, pending, at.Add(time.Hour)))
+
+		pending, err = repos.PR.GetPendingReviews(ctx)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		require.NotNil(t, pending[0].OverdueAt)
+		assert.WithinDuration(t, at, *pending[0].OverdueAt, time.Millisecond)
+
+		overdue, err := repos.PR.GetOverdueReviews(ctx, "Team")
+		require.NoError(t, err)
+		require.Len(t, overdue, 1)
+		assert.Equal(t, "r1", overdue[0].ReviewerID)
+		overdue, err = repos.PR.GetOverdueReviews(ctx, "")
+		require.NoError(t, err)
+		assert.Len(t, overdue, 1)
+		overdue, err = repos.PR.GetOverdueReviews(ctx, "Slow")
+		require.NoError(t, err)
+		assert.Empty(t, overdue)
+
+		pr, err = repos.PR.GetByID(ctx, pr.ID)
+		require.NoError(t, err)
+		pr.AssignedReviewers = []string{"r3", "r2"}
+		pr.ChangeReason = domains.ReplacementSLATimeout
+		require.NoError(t, repos.PR.Update(ctx, pr))
+
+		timedOut, err := repos.PR.GetTimedOutReviewers(ctx, "pr-sla")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"r1"}, timedOut)
+
+		events, err := repos.PR.GetEvents(ctx, "pr-sla")
+		require.NoError(t, err)
+		assert.Equal(t, []domains.PREventType{
+			domains.PREventCreated,
+			domains.PREventReviewerAssigned,
+			domains.PREventReviewerAssigned,
+			domains.PREventReviewSubmitted,
+			domains.PREventReviewOverdue,
+			domains.PREventReviewerReplaced,
+		}, eventTypesOf(events))
+		assert.Equal(t, "no review within 4 working hours", events[4].Reason)
+	})
+}
+
+func TestRepositoryContract_DeactivationReassignsReviewers(t *testing.T) {
+	runContract(t, func(t *testing.T, repos contractRepos) {
+		ctx := context.Background()
+		seedTeam(t, repos, &domains.Team{Name: "Team"}, "author", "r1", "r2", "r3")
+		seedTeam(t, repos, &domains.Team{Name: "Leaving"}, "l1", "l2")
+
+		open := seedPR(t, repos, "pr-open", "author", "r1", "l1")
+		closed := seedPR(t, repos, "pr-closed", "author", "l2")
+		closed.Status = domains.PRStatusClosed
+		closed.AssignedReviewers = []string{}
+		require.NoError(t, repos.PR.Update(ctx, closed))
+
+		open.AssignedReviewers = []string{"r1", "r3"}
+		require.NoError(t, repos.User.DeactivateTeamMembers(ctx, "Leaving", []*domains.PullRequest{open, closed}))
+
+		team, err := repos.Team.GetByName(ctx, "Leaving")
+		require.NoError(t, err)
+		for _, member := range team.Members {
+			assert.False(t, member.IsActive, member.UserID)
+		}
+
+		fetched, err := repos.PR.GetByID(ctx, "pr-open")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"r1", "r3"}, fetched.AssignedReviewers)
+		assert.Equal(t, open.Version, fetched.Version)
+
+		events, err := repos.PR.GetEvents(ctx, "pr-open")
+		require.NoError(t, err)
+		last := events[len(events)-1]
+		assert.Equal(t, domains.PREventReviewerReplaced, last.Type)
+		assert.Equal(t, domains.ReplacementDeactivated, last.Reason)
+
+		fetched, err = repos.PR.GetByID(ctx, "pr-closed")
+		require.NoError(t, err)
+		assert.Equal(t, closed.Version, fetched.Version)
+		assert.Empty(t, fetched.AssignedReviewers)
+
+		require.NoError(t, repos.User.DeactivateUsers(ctx, []string{"r3"}, nil))
+		user, err := repos.User.GetByID(ctx, "r3")
+		require.NoError(t, err)
+		assert.False(t, user.IsActive)
+	})
+}
+
+func TestRepositoryContract_OwnershipRules(t *testing.T) {
+	runContract(t, func(t *testing.T, repos contractRepos) {
+		ctx := context.Background()
+		seedTeam(t, repos, &domains.Team{Name: "Team"}, "o1")
+
+		rules := []domains.OwnershipRule{
+			{Pattern: "/api/", Owners: []string{"o1"}},
+			{Pattern: "*.md"},
+		}
+		require.NoError(t, repos.Ownership.ReplaceRules(ctx, "Team", rules))
+		require.NoError(t, repos.Ownership.ReplaceRules(ctx, "Team", rules[:1]))
+		assert.Error(t, repos.Ownership.ReplaceRules(ctx, "Nobody", rules))
+
+		stored, err := repos.Ownership.GetRules(ctx, "Team")
+		require.NoError(t, err)
+		assert.Equal(t, []domains.OwnershipRule{{Pattern: "/api/", Owners: []string{"o1"}}}, stored)
+
+		stored, err = repos.Ownership.GetRules(ctx, "Nobody")
+		require.NoError(t, err)
+		assert.Empty(t, stored)
+	})
+}
+
+func TestRepositoryContract_UnitOfWork(t *testing.T) {
+	runContract(t, func(t *testing.T, repos contractRepos) {
+		ctx := context.Background()
+		seedTeam(t, repos, &domains.Team{Name: "Team"}, "author", "r1")
+
+		err := repos.UnitOfWork.WithTx(ctx, func(tx repository.Repositories) error {
+			return tx.PR.Create(ctx, &domains.PullRequest{
+				ID: "pr-commit", Name: "Committed", AuthorID: "author", Status: domains.PRStatusOpen,
+			})
+		})
+		require.NoError(t, err)
+		exists, err := repos.PR.Exists(ctx, "pr-commit")
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		failure := errors.New("abort")
+		err = repos.UnitOfWork.WithTx(ctx, func(tx repository.Repositories) error {
+			if err := tx.User.UpdateActivity(ctx, "r1", false); err != nil {
+				return err
+			}
+			if err := tx.PR.Create(ctx, &domains.PullRequest{
+				ID: "pr-rollback", Name: "Rolled back", AuthorID: "author", Status: domains.PRStatusOpen,
+			}); err != nil {
+				return err
+			}
+
+			exists, err := tx.PR.Exists(ctx, "pr-rollback")
+			require.NoError(t, err)
+			assert.True(t, exists, "the transaction must see its own writes")
+			return failure
+		})
+		assert.ErrorIs(t, err, failure)
+
+		exists, err = repos.PR.Exists(ctx, "pr-rollback")
+		require.NoError(t, err)
+		assert.False(t, exists)
+		user, err := repos.User.GetByID(ctx, "r1")
+		require.NoError(t, err)
+		assert.True(t, user.IsActive)
+	})
+}
+
+func countNil(errs []error) int {
+	count := 0
+	for _, err := range errs {
+		if err == nil {
+			count++
+		}
+	}
+	return count
+}
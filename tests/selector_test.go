@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ReviewerAssignmentService/internal/domains"
+	"ReviewerAssignmentService/internal/service"
+)
+
+func TestReviewerSelector_Select(t *testing.T) {
+	candidates := []domains.ReviewCandidate{
+		{UserID: "u3", OpenReviews: 4},
+		{UserID: "u1", OpenReviews: 2},
+		{UserID: "u2", OpenReviews: 0},
+	}
+
+	testCases := []struct {
+		name     string
+		strategy string
+		limit    int
+		expected []string
+	}{
+		{
+			name:     "Random keeps repository order",
+			strategy: service.StrategyRandom,
+			limit:    2,
+			expected: []string{"u3", "u1"},
+		},
+		{
+			name:     "Least loaded prefers fewest open reviews",
+			strategy: service.StrategyLeastLoaded,
+			limit:    2,
+			expected: []string{"u2", "u1"},
+		},
+		{
+			name:     "Limit larger than pool",
+			strategy: service.StrategyLeastLoaded,
+			limit:    5,
+			expected: []string{"u2", "u1", "u3"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			selector, err := service.NewReviewerSelector(tc.strategy)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, selector.Select("T1", candidates, tc.limit))
+		})
+	}
+}
+
+func TestReviewerSelector_LeastLoadedTies(t *testing.T) {
+	selector, err := service.NewReviewerSelector(service.StrategyLeastLoaded)
+	require.NoError(t, err)
+
+	candidates := []domains.ReviewCandidate{{UserID: "b", OpenReviews: 1}, {UserID: "c"}, {UserID: "a"}}
+
+	assert.Equal(t, []string{"c", "a"}, selector.Select("T1", candidates, 2))
+}
+
+func TestReviewerSelector_RoundRobin(t *testing.T) {
+	selector, err := service.NewReviewerSelector(service.StrategyRoundRobin)
+	require.NoError(t, err)
+
+	candidates := []domains.ReviewCandidate{{UserID: "c"}, {UserID: "a"}, {UserID: "b"}}
+
+	assert.Equal(t, []string{"a", "b"}, selector.Select("T1", candidates, 2))
+	assert.Equal(t, []string{"c", "a"}, selector.Select("T1", candidates, 2))
+	assert.Equal(t, []string{"a"}, selector.Select("T2", candidates, 1))
+}
+
+func TestReviewerSelector_Weighted(t *testing.T) {
+	selector, err := service.NewReviewerSelector(service.StrategyWeighted)
+	require.NoError(t, err)
+
+	candidates := []domains.ReviewCandidate{{UserID: "a", OpenReviews: 1}, {UserID: "b"}, {UserID: "c", OpenReviews: 9}}
+
+	selected := selector.Select("T1", candidates, 2)
+	assert.Len(t, selected, 2)
+	assert.NotEqual(t, selected[0], selected[1])
+}
+
+func TestReviewerSelector_UnknownStrategy(t *testing.T) {
+	_, err := service.NewReviewerSelector("lottery")
+	assert.ErrorIs(t, err, service.ErrUnknownStrategy)
+	assert.NoError(t, service.ValidateStrategy(""))
+}
@@ -3,11 +3,13 @@ package tests
 import (
 	"context"
 	"testing"
+	"time"
 
 	"ReviewerAssignmentService/mocks"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"ReviewerAssignmentService/internal/domains"
 	"ReviewerAssignmentService/internal/service"
@@ -20,15 +22,17 @@ func TestService_CreatePR(t *testing.T) {
 	tests := []struct {
 		name       string
 		args       args
-		setupMocks func(prRepo *mocks.PRRepository, userRepo *mocks.UserRepository)
+		setupMocks func(prRepo *mocks.PRRepository, userRepo *mocks.UserRepository, teamRepo *mocks.TeamRepository)
 		wantErr    bool
 	}{
 		{
 			name: "Success",
 			args: args{input: domains.PullRequestInput{ID: "pr-1", AuthorID: "u1"}},
-			setupMocks: func(prRepo *mocks.PRRepository, userRepo *mocks.UserRepository) {
+			setupMocks: func(prRepo *mocks.PRRepository, userRepo *mocks.UserRepository, teamRepo *mocks.TeamRepository) {
 				userRepo.On("GetByID", mock.Anything, "u1").Return(&domains.User{ID: "u1", TeamName: "A"}, nil)
-				userRepo.On("GetRandomActiveUsersByTeam", mock.Anything, "A", "u1", 2).Return([]string{"u2", "u3"}, nil)
+				teamRepo.On("GetSettings", mock.Anything, "A").Return(&domains.TeamSettings{}, nil)
+				userRepo.On("GetActiveCandidatesByTeam", mock.Anything, "A", []string{"u1"}).
+					Return([]domains.ReviewCandidate{{UserID: "u2"}, {UserID: "u3"}}, nil)
 				prRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
 			},
 			wantErr: false,
@@ -39,9 +43,10 @@ func TestService_CreatePR(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mPR := mocks.NewPRRepository(t)
 			mUser := mocks.NewUserRepository(t)
-			tt.setupMocks(mPR, mUser)
+			mTeam := mocks.NewTeamRepository(t)
+			tt.setupMocks(mPR, mUser, mTeam)
 
-			s := service.NewPRService(mPR, mUser)
+			s := newPRService(t, mPR, mUser, mTeam, mocks.NewOwnershipRepository(t), service.AssignmentConfig{})
 			_, err := s.CreatePR(context.Background(), tt.args.input)
 
 			if tt.wantErr {
@@ -56,10 +61,12 @@ func TestService_CreatePR(t *testing.T) {
 func TestService_MergePR(t *testing.T) {
 	mPR := mocks.NewPRRepository(t)
 	mUser := mocks.NewUserRepository(t)
-	s := service.NewPRService(mPR, mUser)
+	s := newPRService(t, mPR, mUser, mocks.NewTeamRepository(t), mocks.NewOwnershipRepository(t),
+		service.AssignmentConfig{})
 
-	pr := &domains.PullRequest{ID: "pr-1", Status: domains.PRStatusOpen}
+	pr := &domains.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domains.PRStatusOpen}
 	mPR.On("GetByID", mock.Anything, "pr-1").Return(pr, nil)
+	mUser.On("GetByID", mock.Anything, "u1").Return(nil, nil)
 	mPR.On("Update", mock.Anything, mock.MatchedBy(func(p *domains.PullRequest) bool {
 		return p.Status == domains.PRStatusMerged
 	})).Return(nil)
@@ -69,3 +76,141 @@ func TestService_MergePR(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, domains.PRStatusMerged, got.Status)
 }
+
+func TestService_AddUnavailability(t *testing.T) {
+	start := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		period     domains.Unavailability
+		setupMocks func(userRepo *mocks.UserRepository)
+		wantErr    error
+	}{
+		{
+			name: "Success",
+			period: domains.Unavailability{
+				UserID: "u1", StartsAt: start, EndsAt: start.Add(72 * time.Hour), Reason: domains.UnavailabilityVacation,
+			},
+			setupMocks: func(userRepo *mocks.UserRepository) {
+				userRepo.On("Exists", mock.Anything, "u1").Return(true, nil)
+				userRepo.On("AddUnavailability", mock.Anything, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name: "Ends before start",
+			period: domains.Unavailability{
+				UserID: "u1", StartsAt: start, EndsAt: start, Reason: domains.UnavailabilitySickLeave,
+			},
+			setupMocks: func(userRepo *mocks.UserRepository) {},
+			wantErr:    service.ErrInvalidPeriod,
+		},
+		{
+			name: "Unknown reason",
+			period: domains.Unavailability{
+				UserID: "u1", StartsAt: start, EndsAt: start.Add(time.Hour), Reason: "PARTY",
+			},
+			setupMocks: func(userRepo *mocks.UserRepository) {},
+			wantErr:    service.ErrUnknownUnavailability,
+		},
+		{
+			name: "Unknown user",
+			period: domains.Unavailability{
+				UserID: "ghost", StartsAt: start, EndsAt: start.Add(time.Hour), Reason: domains.UnavailabilityOther,
+			},
+			setupMocks: func(userRepo *mocks.UserRepository) {
+				userRepo.On("Exists", mock.Anything, "ghost").Return(false, nil)
+			},
+			wantErr: service.ErrUserFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mUser := mocks.NewUserRepository(t)
+			tt.setupMocks(mUser)
+
+			s := service.NewUserService(mUser, mocks.NewPRRepository(t))
+			_, err := s.AddUnavailability(context.Background(), &tt.period)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestService_OwnerApprovalSurvivesReassignment(t *testing.T) {
+	runContract(t, func(t *testing.T, repos contractRepos) {
+		ctx := context.Background()
+		seedTeam(t, repos, &domains.Team{
+			Name: "Team",
+			TeamSettings: domains.TeamSettings{
+				RequiredReviewers: 2,
+				MergePolicy:       domains.MergePolicy{RequireOwnerApproval: true},
+			},
+		}, "author", "owner", "r1", "r2")
+		require.NoError(t, repos.Ownership.ReplaceRules(ctx, "Team", []domains.OwnershipRule{
+			{Pattern: "api/", Owners: []string{"owner"}},
+		}))
+		s := service.NewPRService(repos.PR, repos.User, repos.Team, repos.Ownership, repos.UnitOfWork,
+			service.AssignmentConfig{})
+
+		pr, err := s.CreatePR(ctx, domains.PullRequestInput{
+			ID: "pr-owned", Name: "Owned", AuthorID: "author", ChangedFiles: []string{"api/handler.go"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"owner"}, pr.OwnerReviewers)
+
+		pr, _, err = s.UpdateReviewer(ctx, "pr-owned", "owner", "")
+		require.NoError(t, err)
+		require.Empty(t, pr.OwnerReviewers)
+		for _, reviewer := range pr.AssignedReviewers {
+			_, err = s.SubmitReview(ctx, "pr-owned", reviewer, domains.ReviewStateApproved)
+			require.NoError(t, err)
+		}
+
+		_, err = s.MergePR(ctx, "pr-owned")
+		assert.ErrorIs(t, err, service.ErrMergeBlocked)
+		assert.EqualError(t, err, "merge requirements not met: no approval from a code owner")
+	})
+}
+
+func TestService_ReopenAssignsCodeOwners(t *testing.T) {
+	runContract(t, func(t *testing.T, repos contractRepos) {
+		ctx := context.Background()
+		seedTeam(t, repos, &domains.Team{
+			Name: "Team",
+			TeamSettings: domains.TeamSettings{
+				RequiredReviewers: 2,
+				MergePolicy:       domains.MergePolicy{RequireOwnerApproval: true},
+			},
+		}, "author", "owner", "r1", "r2")
+		require.NoError(t, repos.Ownership.ReplaceRules(ctx, "Team", []domains.OwnershipRule{
+			{Pattern: "api/", Owners: []string{"owner"}},
+		}))
+		s := service.NewPRService(repos.PR, repos.User, repos.Team, repos.Ownership, repos.UnitOfWork,
+			service.AssignmentConfig{})
+
+		_, err := s.CreatePR(ctx, domains.PullRequestInput{
+			ID: "pr-owned", Name: "Owned", AuthorID: "author", ChangedFiles: []string{"api/handler.go"},
+		})
+		require.NoError(t, err)
+		_, err = s.ClosePR(ctx, "pr-owned")
+		require.NoError(t, err)
+
+		pr, err := s.ReopenPR(ctx, "pr-owned")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"owner"}, pr.OwnerReviewers)
+		assert.Contains(t, pr.AssignedReviewers, "owner")
+
+		for _, reviewer := range pr.AssignedReviewers {
+			_, err = s.SubmitReview(ctx, "pr-owned", reviewer, domains.ReviewStateApproved)
+			require.NoError(t, err)
+		}
+		merged, err := s.MergePR(ctx, "pr-owned")
+		require.NoError(t, err)
+		assert.Equal(t, domains.PRStatusMerged, merged.Status)
+	})
+}
@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ReviewerAssignmentService/internal/domains"
+	"ReviewerAssignmentService/internal/repository"
+	"ReviewerAssignmentService/internal/service"
+	"ReviewerAssignmentService/mocks"
+)
+
+// newPRService builds a PRService whose transactions run directly against the
+// given repositories.
+func newPRService(t *testing.T, prRepo repository.PRRepository, userRepo repository.UserRepository,
+	teamRepo repository.TeamRepository, ownershipRepo repository.OwnershipRepository,
+	assignment service.AssignmentConfig) service.PRService {
+	unitOfWork := passThroughUnitOfWork(t, repository.Repositories{
+		PR:        prRepo,
+		User:      userRepo,
+		Team:      teamRepo,
+		Ownership: ownershipRepo,
+	})
+	return service.NewPRService(prRepo, userRepo, teamRepo, ownershipRepo, unitOfWork, assignment)
+}
+
+func passThroughUnitOfWork(t *testing.T, repos repository.Repositories) *mocks.UnitOfWork {
+	unitOfWork := mocks.NewUnitOfWork(t)
+	unitOfWork.On("WithTx", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, fn func(repository.Repositories) error) error {
+			return fn(repos)
+		}).
+		Maybe()
+	return unitOfWork
+}
+
+func TestPRService_CreatePR_UsesTransactionRepositories(t *testing.T) {
+	txPR := mocks.NewPRRepository(t)
+	txUser := mocks.NewUserRepository(t)
+	txTeam := mocks.NewTeamRepository(t)
+
+	txUser.On("GetByID", mock.Anything, "author").Return(&domains.User{ID: "author", TeamName: "Team"}, nil)
+	txTeam.On("GetSettings", mock.Anything, "Team").Return(&domains.TeamSettings{}, nil)
+	txUser.On("GetActiveCandidatesByTeam", mock.Anything, "Team", []string{"author"}).
+		Return([]domains.ReviewCandidate{{UserID: "r1"}, {UserID: "r2"}}, nil)
+	txPR.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	unitOfWork := passThroughUnitOfWork(t, repository.Repositories{
+		PR:        txPR,
+		User:      txUser,
+		Team:      txTeam,
+		Ownership: mocks.NewOwnershipRepository(t),
+	})
+	// The pool-bound repositories have no expectations: any call outside the
+	// transaction fails the test.
+	svc := service.NewPRService(mocks.NewPRRepository(t), mocks.NewUserRepository(t), mocks.NewTeamRepository(t),
+		mocks.NewOwnershipRepository(t), unitOfWork, service.AssignmentConfig{})
+
+	pr, err := svc.CreatePR(context.Background(), domains.PullRequestInput{ID: "pr1", Name: "PR", AuthorID: "author"})
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"r1", "r2"}, pr.AssignedReviewers)
+}
+
+func TestPRService_CommitFailure(t *testing.T) {
+	prRepo := mocks.NewPRRepository(t)
+	prRepo.On("GetByID", mock.Anything, "pr1").Return(&domains.PullRequest{
+		ID:     "pr1",
+		Status: domains.PRStatusOpen,
+	}, nil)
+	prRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	commitErr := errors.New("commit failed")
+	unitOfWork := mocks.NewUnitOfWork(t)
+	unitOfWork.On("WithTx", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, fn func(repository.Repositories) error) error {
+			if err := fn(repository.Repositories{PR: prRepo}); err != nil {
+				return err
+			}
+			return commitErr
+		})
+
+	svc := service.NewPRService(prRepo, mocks.NewUserRepository(t), mocks.NewTeamRepository(t),
+		mocks.NewOwnershipRepository(t), unitOfWork, service.AssignmentConfig{})
+	pr, err := svc.ClosePR(context.Background(), "pr1")
+
+	assert.ErrorIs(t, err, commitErr)
+	assert.Nil(t, pr)
+}
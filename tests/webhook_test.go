@@ -0,0 +1,214 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"ReviewerAssignmentService/internal/domains"
+	"ReviewerAssignmentService/internal/service"
+	"ReviewerAssignmentService/mocks"
+)
+
+func TestWebhookService_CreateSubscription(t *testing.T) {
+	testCases := []struct {
+		name        string
+		sub         domains.WebhookSubscription
+		expectError error
+	}{
+		{
+			name: "OK: Defaults to all events",
+			sub:  domains.WebhookSubscription{URL: "https://hooks.example.com/pr", Secret: "s"},
+		},
+		{
+			name:        "Error: Relative URL",
+			sub:         domains.WebhookSubscription{URL: "/pr", Secret: "s"},
+			expectError: service.ErrInvalidWebhookURL,
+		},
+		{
+			name:        "Error: Missing secret",
+			sub:         domains.WebhookSubscription{URL: "https://hooks.example.com/pr"},
+			expectError: service.ErrMissingWebhookSecret,
+		},
+		{
+			name: "Error: Unknown event",
+			sub: domains.WebhookSubscription{URL: "https://hooks.example.com/pr", Secret: "s",
+				Events: []domains.PREventType{"deleted"}},
+			expectError: service.ErrUnknownWebhookEvent,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewWebhookRepository(t)
+			if tc.expectError == nil {
+				repo.On("CreateSubscription", mock.Anything, mock.Anything).Return(nil)
+			}
+
+			svc := service.NewWebhookService(repo, service.WebhookConfig{})
+			sub, err := svc.CreateSubscription(context.Background(), &tc.sub)
+
+			if tc.expectError != nil {
+				assert.ErrorIs(t, err, tc.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, domains.WebhookEvents, sub.Events)
+		})
+	}
+}
+
+// newWebhookReceiver serves webhooks with the status returned by respond for
+// each delivered event and counts the requests it received.
+func newWebhookReceiver(t *testing.T, respond func(event domains.PREvent) int) (*httptest.Server, *atomic.Int32) {
+	var calls atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, service.WebhookSignature("secret", body), r.Header.Get(service.WebhookSignatureHeader))
+
+		var event domains.PREvent
+		assert.NoError(t, json.Unmarshal(body, &event))
+		assert.Equal(t, string(event.Type), r.Header.Get(service.WebhookEventHeader))
+		assert.Equal(t, strconv.FormatInt(event.ID, 10), r.Header.Get(service.WebhookDeliveryHeader))
+
+		calls.Add(1)
+		w.WriteHeader(respond(event))
+	}))
+	t.Cleanup(receiver.Close)
+	return receiver, &calls
+}
+
+func subscribeCreated(t *testing.T, repos contractRepos, url string) *domains.WebhookSubscription {
+	sub := &domains.WebhookSubscription{URL: url, Secret: "secret", Events: []domains.PREventType{domains.PREventCreated}}
+	require.NoError(t, repos.Webhook.CreateSubscription(context.Background(), sub))
+	return sub
+}
+
+func TestWebhookService_DispatchPending(t *testing.T) {
+	runContract(t, func(t *testing.T, repos contractRepos) {
+		ctx := context.Background()
+		seedTeam(t, repos, &domains.Team{Name: "backend"}, "u1")
+
+		var failed atomic.Bool
+		receiver, calls := newWebhookReceiver(t, func(event domains.PREvent) int {
+			if event.PullRequestID == "pr1" && failed.CompareAndSwap(false, true) {
+				return http.StatusInternalServerError
+			}
+			return http.StatusOK
+		})
+		sub := subscribeCreated(t, repos, receiver.URL)
+		svc := service.NewWebhookService(repos.Webhook, service.WebhookConfig{MaxAttempts: 3, BaseBackoff: time.Minute})
+
+		seedPR(t, repos, "pr1", "u1")
+		seedPR(t, repos, "pr2", "u1")
+		now := time.Now()
+
+		delivered, err := svc.DispatchPending(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, delivered, "the failed event must not hold back the next one")
+
+		delivered, err = svc.DispatchPending(ctx, now.Add(30*time.Second))
+		require.NoError(t, err)
+		assert.Zero(t, delivered)
+		assert.EqualValues(t, 2, calls.Load(), "a failed event is not retried before its backoff elapsed")
+
+		seedPR(t, repos, "pr3", "u1")
+		delivered, err = svc.DispatchPending(ctx, now.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 2, delivered, "the retried event and the new one are delivered")
+
+		delivered, err = svc.DispatchPending(ctx, now.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Zero(t, delivered)
+		assert.EqualValues(t, 4, calls.Load())
+
+		deliveries, err := repos.Webhook.GetDeliveries(ctx, sub.ID, 10)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 4)
+		retry := deliveries[1]
+		assert.Equal(t, 2, retry.Attempt)
+		assert.True(t, retry.Success)
+		first := deliveries[3]
+		assert.Equal(t, retry.EventID, first.EventID)
+		assert.False(t, first.Success)
+		require.NotNil(t, first.NextAttemptAt)
+		assert.WithinDuration(t, now.Add(time.Minute), *first.NextAttemptAt, time.Millisecond)
+	})
+}
+
+func TestWebhookService_DispatchRetriesExhausted(t *testing.T) {
+	runContract(t, func(t *testing.T, repos contractRepos) {
+		ctx := context.Background()
+		seedTeam(t, repos, &domains.Team{Name: "backend"}, "u1")
+
+		receiver, calls := newWebhookReceiver(t, func(domains.PREvent) int { return http.StatusInternalServerError })
+		sub := subscribeCreated(t, repos, receiver.URL)
+		svc := service.NewWebhookService(repos.Webhook, service.WebhookConfig{MaxAttempts: 2, BaseBackoff: time.Minute})
+
+		seedPR(t, repos, "pr1", "u1")
+		now := time.Now()
+		for _, at := range []time.Time{now, now.Add(time.Minute), now.Add(time.Hour)} {
+			delivered, err := svc.DispatchPending(ctx, at)
+			require.NoError(t, err)
+			assert.Zero(t, delivered)
+		}
+		assert.EqualValues(t, 2, calls.Load(), "an event leaves the queue once its attempts are exhausted")
+
+		deliveries, err := repos.Webhook.GetDeliveries(ctx, sub.ID, 10)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 2)
+		assert.Equal(t, 2, deliveries[0].Attempt)
+		assert.Equal(t, http.StatusInternalServerError, deliveries[0].StatusCode)
+		assert.Nil(t, deliveries[0].NextAttemptAt)
+	})
+}
+
+func TestWebhookService_DispatchSubscriptionsIndependently(t *testing.T) {
+	runContract(t, func(t *testing.T, repos contractRepos) {
+		ctx := context.Background()
+		seedTeam(t, repos, &domains.Team{Name: "backend"}, "u1")
+
+		release := make(chan struct{})
+		releaseSlow := sync.OnceFunc(func() { close(release) })
+		slow, _ := newWebhookReceiver(t, func(domains.PREvent) int {
+			<-release
+			return http.StatusOK
+		})
+		t.Cleanup(releaseSlow)
+		received := make(chan struct{}, 1)
+		fast, _ := newWebhookReceiver(t, func(domains.PREvent) int {
+			received <- struct{}{}
+			return http.StatusOK
+		})
+		subscribeCreated(t, repos, slow.URL)
+		subscribeCreated(t, repos, fast.URL)
+		svc := service.NewWebhookService(repos.Webhook, service.WebhookConfig{})
+
+		seedPR(t, repos, "pr1", "u1")
+		done := make(chan int)
+		go func() {
+			delivered, err := svc.DispatchPending(ctx, time.Now())
+			assert.NoError(t, err)
+			done <- delivered
+		}()
+
+		select {
+		case <-received:
+		case <-time.After(5 * time.Second):
+			t.Fatal("a slow receiver held back the other subscription")
+		}
+		releaseSlow()
+		assert.Equal(t, 2, <-done)
+	})
+}